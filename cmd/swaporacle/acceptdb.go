@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/worker"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	acceptDBFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.ConfigFileFlag,
+		utils.VerbosityFlag,
+	}
+
+	prefixFlag = &cli.StringFlag{
+		Name:  "prefix",
+		Usage: "only list records which key has this prefix",
+	}
+	limitFlag = &cli.IntFlag{
+		Name:  "limit",
+		Usage: "max number of records to list (0 means no limit)",
+		Value: 100,
+	}
+	pairIDFlag = &cli.StringFlag{
+		Name:  "pairid",
+		Usage: "pairID of swap",
+	}
+	bindFlag = &cli.StringFlag{
+		Name:  "bind",
+		Usage: "bind address of swap",
+	}
+	daysFlag = &cli.Uint64Flag{
+		Name:  "days",
+		Usage: "prune records older than this days",
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "file format (json or csv)",
+		Value: worker.AcceptRecordJSONFormat,
+	}
+	outputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "output file",
+	}
+	inputFlag = &cli.StringFlag{
+		Name:  "input",
+		Usage: "input file",
+	}
+
+	acceptDBCommand = &cli.Command{
+		Name:  "accept-db",
+		Usage: "inspect and maintain accept database",
+		Description: `
+inspect and maintain accept database of agreed swaps.
+the oracle must be stopped as the database can be opened by one process only.
+pruning by days only deletes expired records and keeps done markers,
+done markers are only added by the retention job after checking swap tx on chain.
+import only accepts swap tx records and nonce records.
+`,
+		Subcommands: []*cli.Command{
+			{
+				Action:    listAcceptRecords,
+				Name:      "list",
+				Usage:     "list accept records",
+				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{prefixFlag, limitFlag}, acceptDBFlags...),
+			},
+			{
+				Action:    queryAcceptRecords,
+				Name:      "query",
+				Usage:     "query accept records of swap",
+				ArgsUsage: "<txid>",
+				Flags:     append([]cli.Flag{pairIDFlag, bindFlag}, acceptDBFlags...),
+			},
+			{
+				Action:    pruneAcceptRecords,
+				Name:      "prune",
+				Usage:     "prune accept records older than specified days",
+				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{daysFlag}, acceptDBFlags...),
+			},
+			{
+				Action:    exportAcceptRecords,
+				Name:      "export",
+				Usage:     "export accept records to json or csv file",
+				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{formatFlag, outputFlag}, acceptDBFlags...),
+			},
+			{
+				Action:    importAcceptRecords,
+				Name:      "import",
+				Usage:     "import accept records from json or csv file",
+				ArgsUsage: " ",
+				Flags:     append([]cli.Flag{formatFlag, inputFlag}, acceptDBFlags...),
+			},
+		},
+	}
+)
+
+func openAcceptDB(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	dataDir := utils.GetDataDir(ctx)
+	if dataDir == "" {
+		return fmt.Errorf("must specify '--%v'", utils.DataDirFlag.Name)
+	}
+	params.SetDataDir(dataDir)
+	params.CustomizeConfigFunc = func(config *params.BridgeConfig) {
+		if config.Oracle != nil {
+			config.Oracle.NoCheckServerConnection = true
+		}
+	}
+	params.LoadConfig(utils.GetConfigFilePath(ctx), false)
+	worker.OpenAcceptDB()
+	return nil
+}
+
+func printAcceptRecord(record *worker.AcceptRecord) {
+	fmt.Printf("%v %v\n", time.Unix(record.Timestamp, 0).Format(time.RFC3339), record.Key)
+}
+
+func listAcceptRecords(ctx *cli.Context) error {
+	if err := openAcceptDB(ctx); err != nil {
+		return err
+	}
+	defer worker.CloseAcceptDB()
+
+	limit := ctx.Int(limitFlag.Name)
+	count := 0
+	err := worker.IterateAcceptRecords(ctx.String(prefixFlag.Name), func(record *worker.AcceptRecord) bool {
+		printAcceptRecord(record)
+		count++
+		return limit <= 0 || count < limit
+	})
+	log.Printf("list accept records count %v", count)
+	return err
+}
+
+func queryAcceptRecords(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		_ = cli.ShowCommandHelp(ctx, "query")
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+	if err := openAcceptDB(ctx); err != nil {
+		return err
+	}
+	defer worker.CloseAcceptDB()
+
+	records, err := worker.FindAcceptRecordsOfSwap(ctx.Args().Get(0), ctx.String(pairIDFlag.Name), ctx.String(bindFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		printAcceptRecord(record)
+	}
+	log.Printf("query accept records count %v", len(records))
+	return nil
+}
+
+func pruneAcceptRecords(ctx *cli.Context) error {
+	days := ctx.Uint64(daysFlag.Name)
+	if days == 0 {
+		return fmt.Errorf("must specify '--%v'", daysFlag.Name)
+	}
+	if err := openAcceptDB(ctx); err != nil {
+		return err
+	}
+	defer worker.CloseAcceptDB()
+
+	before := time.Now().Add(-time.Duration(days) * 24 * time.Hour).Unix()
+	count, err := worker.PruneAcceptRecords(before)
+	if err != nil {
+		return err
+	}
+	log.Printf("prune accept records older than %v days, count %v", days, count)
+	return nil
+}
+
+func exportAcceptRecords(ctx *cli.Context) error {
+	output := ctx.String(outputFlag.Name)
+	if output == "" {
+		return fmt.Errorf("must specify '--%v'", outputFlag.Name)
+	}
+	if err := openAcceptDB(ctx); err != nil {
+		return err
+	}
+	defer worker.CloseAcceptDB()
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := worker.ExportAcceptRecords(file, ctx.String(formatFlag.Name))
+	if err != nil {
+		return err
+	}
+	log.Printf("export accept records count %v", count)
+	return nil
+}
+
+func importAcceptRecords(ctx *cli.Context) error {
+	input := ctx.String(inputFlag.Name)
+	if input == "" {
+		return fmt.Errorf("must specify '--%v'", inputFlag.Name)
+	}
+	file, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = openAcceptDB(ctx); err != nil {
+		return err
+	}
+	defer worker.CloseAcceptDB()
+
+	count, err := worker.ImportAcceptRecords(file, ctx.String(formatFlag.Name))
+	if err != nil {
+		return err
+	}
+	log.Printf("import accept records count %v", count)
+	return nil
+}
@@ -30,6 +30,7 @@ func initApp() {
 	app.Commands = []*cli.Command{
 		utils.LicenseCommand,
 		utils.VersionCommand,
+		acceptDBCommand,
 	}
 	app.Flags = []cli.Flag{
 		utils.DataDirFlag,
@@ -69,4 +69,88 @@ var (
 		Name:  "nonce",
 		Usage: "nonce in transaction",
 	}
+
+	pairIDFlag = &cli.StringFlag{
+		Name:  "pairid",
+		Usage: "token pair ID",
+	}
+	srcGatewayFlag = &cli.StringFlag{
+		Name:  "srcgateway",
+		Usage: "gateway URL of source chain",
+	}
+	dstGatewayFlag = &cli.StringFlag{
+		Name:  "dstgateway",
+		Usage: "gateway URL of destination chain",
+	}
+	srcTokenFlag = &cli.StringFlag{
+		Name:  "srctoken",
+		Usage: "erc20 token address of source chain (empty for native coin)",
+	}
+	dstTokenFlag = &cli.StringFlag{
+		Name:  "dsttoken",
+		Usage: "mapping token address of destination chain",
+	}
+	srcSymbolFlag = &cli.StringFlag{
+		Name:  "srcsymbol",
+		Usage: "symbol of source native coin",
+		Value: "ETH",
+	}
+	srcNameFlag = &cli.StringFlag{
+		Name:  "srcname",
+		Usage: "name of source native coin",
+	}
+	dcrmPubkeyFlag = &cli.StringFlag{
+		Name:  "dcrmpubkey",
+		Usage: "uncompressed dcrm public key",
+	}
+	depositAddressFlag = &cli.StringFlag{
+		Name:  "depositaddress",
+		Usage: "deposit address of source chain (default is dcrm address)",
+	}
+	tokenPriceFlag = &cli.Float64Flag{
+		Name:  "price",
+		Usage: "token price in USD (default is loaded from price feed)",
+	}
+	priceContractFlag = &cli.StringFlag{
+		Name:  "pricecontract",
+		Usage: "token price feed contract",
+	}
+	priceAPIFlag = &cli.StringSliceFlag{
+		Name:  "priceapi",
+		Usage: "gateway URLs of token price feed contract",
+	}
+	maxSwapUSDFlag = &cli.Float64Flag{
+		Name:  "maxswap",
+		Usage: "maximum swap value in USD",
+		Value: 1000000,
+	}
+	minSwapUSDFlag = &cli.Float64Flag{
+		Name:  "minswap",
+		Usage: "minimum swap value in USD",
+		Value: 10,
+	}
+	bigValueUSDFlag = &cli.Float64Flag{
+		Name:  "bigvalue",
+		Usage: "big value threshold in USD",
+		Value: 100000,
+	}
+	maxSwapFeeUSDFlag = &cli.Float64Flag{
+		Name:  "maxfee",
+		Usage: "maximum swap fee in USD",
+		Value: 100,
+	}
+	minSwapFeeUSDFlag = &cli.Float64Flag{
+		Name:  "minfee",
+		Usage: "minimum swap fee in USD",
+		Value: 1,
+	}
+	swapFeeRateFlag = &cli.Float64Flag{
+		Name:  "feerate",
+		Usage: "swap fee rate",
+		Value: 0.001,
+	}
+	outputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "output file (default is '<pairid>.toml')",
+	}
 )
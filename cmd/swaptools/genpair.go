@@ -0,0 +1,451 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// nolint:lll // allow long line of example
+	genPairCommand = &cli.Command{
+		Action:    genPair,
+		Name:      "genpair",
+		Usage:     "generate token pair config from chain data",
+		ArgsUsage: " ",
+		Description: `
+generate token pair config command, query name, symbol, decimals, code hash
+and proxy implementation of tokens on both chains, check dcrm address has
+minter role of mapping token, calc limits from USD values and token price,
+and report every check 'VerifyTokenConfig' would perform.
+the config file is written only if all checks are passed and not dry run.
+
+Example:
+
+./swaptools genpair --pairid USDT --srcgateway http://1.2.3.4:5555 --dstgateway http://5.6.7.8:5555 --srctoken 0x1111111111111111111111111111111111111111 --dsttoken 0x2222222222222222222222222222222222222222 --dcrmpubkey 0x04... --pricecontract 0x3333333333333333333333333333333333333333 --priceapi http://1.2.3.4:5555 --output ./tokenpairs/USDT.toml
+`,
+		Flags: []cli.Flag{
+			pairIDFlag,
+			srcGatewayFlag,
+			dstGatewayFlag,
+			srcTokenFlag,
+			dstTokenFlag,
+			srcSymbolFlag,
+			srcNameFlag,
+			dcrmPubkeyFlag,
+			depositAddressFlag,
+			tokenPriceFlag,
+			priceContractFlag,
+			priceAPIFlag,
+			maxSwapUSDFlag,
+			minSwapUSDFlag,
+			bigValueUSDFlag,
+			maxSwapFeeUSDFlag,
+			minSwapFeeUSDFlag,
+			swapFeeRateFlag,
+			outputFlag,
+			dryRunFlag,
+		},
+	}
+)
+
+type genTokenInfo struct {
+	ID                string
+	Name              string
+	Symbol            string
+	Decimals          uint8
+	Description       string
+	ContractAddress   string
+	ContractCodeHash  string
+	Implementation    string
+	DepositAddress    string
+	MaximumSwap       string
+	MinimumSwap       string
+	BigValueThreshold string
+	SwapFeeRate       string
+	MaximumSwapFee    string
+	MinimumSwapFee    string
+}
+
+type genCheckResult struct {
+	name   string
+	detail string
+	err    error
+}
+
+type pairGenerator struct {
+	pairID         string
+	srcGateway     string
+	dstGateway     string
+	srcToken       string
+	dstToken       string
+	srcSymbol      string
+	srcName        string
+	dcrmPubkey     string
+	dcrmAddress    string
+	depositAddress string
+	price          float64
+	priceCfg       *tokens.TokenPriceConfig
+	output         string
+	dryRun         bool
+
+	maxSwapUSD    float64
+	minSwapUSD    float64
+	bigValueUSD   float64
+	maxSwapFeeUSD float64
+	minSwapFeeUSD float64
+	swapFeeRate   float64
+
+	srcBridge *eth.Bridge
+	dstBridge *eth.Bridge
+	srcInfo   *genTokenInfo
+	dstInfo   *genTokenInfo
+	checks    []*genCheckResult
+}
+
+var pairGen = &pairGenerator{}
+
+var pairConfigTemplate = template.Must(template.New("pair").Parse(`# generated by 'swaptools genpair'
+PairID = {{printf "%q" .PairID}}
+DiffDecimals = {{.DiffDecimals}}
+{{range .Tokens}}
+[{{.Section}}]
+ID = {{printf "%q" .ID}}
+Name = {{printf "%q" .Name}}
+Symbol = {{printf "%q" .Symbol}}
+Decimals = {{.Decimals}}
+Description = {{printf "%q" .Description}}
+{{- if .Implementation}}
+# proxy implementation is {{.Implementation}}
+{{- end}}
+ContractAddress = "{{.ContractAddress}}"
+{{- if .ContractCodeHash}}
+ContractCodeHash = "{{.ContractCodeHash}}"
+{{- end}}
+{{- if .DepositAddress}}
+DepositAddress = "{{.DepositAddress}}"
+{{- end}}
+DcrmAddress = "{{$.DcrmAddress}}"
+DcrmPubkey = "{{$.DcrmPubkey}}"
+MaximumSwap = {{.MaximumSwap}}
+MinimumSwap = {{.MinimumSwap}}
+BigValueThreshold = {{.BigValueThreshold}}
+SwapFeeRate = {{.SwapFeeRate}}
+MaximumSwapFee = {{.MaximumSwapFee}}
+MinimumSwapFee = {{.MinimumSwapFee}}
+DisableSwap = false
+{{end}}`))
+
+func genPair(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	pairGen.initArgs(ctx)
+	pairGen.initBridges()
+
+	pairGen.srcInfo = pairGen.queryToken(pairGen.srcBridge, pairGen.srcToken, true)
+	pairGen.dstInfo = pairGen.queryToken(pairGen.dstBridge, pairGen.dstToken, false)
+	if pairGen.srcInfo == nil || pairGen.dstInfo == nil {
+		pairGen.printReport()
+		log.Fatal("query token info failed")
+	}
+	pairGen.checkMinterRole()
+
+	content, err := pairGen.genConfig()
+	if err != nil {
+		pairGen.printReport()
+		log.Fatal("generate token pair config failed", "err", err)
+	}
+	pairGen.verifyConfig(content)
+
+	passed := pairGen.printReport()
+	if pairGen.dryRun {
+		fmt.Println(string(content))
+		log.Info("------------ dry run, does not write config -------------")
+		return nil
+	}
+	if !passed {
+		log.Fatal("token pair config checks failed, does not write config")
+	}
+	err = ioutil.WriteFile(pairGen.output, content, 0o644)
+	if err != nil {
+		log.Fatal("write token pair config failed", "output", pairGen.output, "err", err)
+	}
+	log.Info("write token pair config success", "output", pairGen.output)
+	return nil
+}
+
+func (g *pairGenerator) initArgs(ctx *cli.Context) {
+	g.pairID = ctx.String(pairIDFlag.Name)
+	g.srcGateway = ctx.String(srcGatewayFlag.Name)
+	g.dstGateway = ctx.String(dstGatewayFlag.Name)
+	g.srcToken = ctx.String(srcTokenFlag.Name)
+	g.dstToken = ctx.String(dstTokenFlag.Name)
+	g.srcSymbol = ctx.String(srcSymbolFlag.Name)
+	g.srcName = ctx.String(srcNameFlag.Name)
+	g.dcrmPubkey = ctx.String(dcrmPubkeyFlag.Name)
+	g.depositAddress = ctx.String(depositAddressFlag.Name)
+	g.price = ctx.Float64(tokenPriceFlag.Name)
+	g.output = ctx.String(outputFlag.Name)
+	g.dryRun = ctx.Bool(dryRunFlag.Name)
+
+	g.maxSwapUSD = ctx.Float64(maxSwapUSDFlag.Name)
+	g.minSwapUSD = ctx.Float64(minSwapUSDFlag.Name)
+	g.bigValueUSD = ctx.Float64(bigValueUSDFlag.Name)
+	g.maxSwapFeeUSD = ctx.Float64(maxSwapFeeUSDFlag.Name)
+	g.minSwapFeeUSD = ctx.Float64(minSwapFeeUSDFlag.Name)
+	g.swapFeeRate = ctx.Float64(swapFeeRateFlag.Name)
+
+	if g.pairID == "" {
+		log.Fatal("must specify '-pairid' flag")
+	}
+	if g.srcGateway == "" || g.dstGateway == "" {
+		log.Fatal("must specify '-srcgateway' and '-dstgateway' flag")
+	}
+	if g.dstToken == "" {
+		log.Fatal("must specify '-dsttoken' flag")
+	}
+	if g.srcToken == "" && g.srcSymbol == "" {
+		log.Fatal("must specify '-srcsymbol' flag if source token is native coin")
+	}
+	if g.srcName == "" {
+		g.srcName = g.srcSymbol
+	}
+
+	pkBytes := common.FromHex(g.dcrmPubkey)
+	if len(pkBytes) != 65 || pkBytes[0] != 4 {
+		log.Fatal("must specify '-dcrmpubkey' flag with uncompressed public key")
+	}
+	pubKey, err := crypto.UnmarshalPubkey(pkBytes)
+	if err != nil {
+		log.Fatal("wrong dcrm public key", "err", err)
+	}
+	g.dcrmPubkey = common.ToHex(pkBytes)[2:]
+	g.dcrmAddress = crypto.PubkeyToAddress(*pubKey).String()
+	if g.depositAddress == "" {
+		g.depositAddress = g.dcrmAddress
+	}
+
+	if priceContract := ctx.String(priceContractFlag.Name); priceContract != "" {
+		g.priceCfg = &tokens.TokenPriceConfig{
+			Contract:   priceContract,
+			APIAddress: ctx.StringSlice(priceAPIFlag.Name),
+		}
+		if len(g.priceCfg.APIAddress) == 0 {
+			g.priceCfg.APIAddress = []string{g.srcGateway}
+		}
+	}
+	if g.price <= 0 && g.priceCfg == nil {
+		log.Fatal("must specify '-price' or '-pricecontract' flag")
+	}
+
+	if g.output == "" {
+		g.output = g.pairID + ".toml"
+	}
+
+	log.Info("initArgs finished", "pairID", g.pairID,
+		"srcGateway", g.srcGateway, "dstGateway", g.dstGateway,
+		"srcToken", g.srcToken, "dstToken", g.dstToken,
+		"dcrmAddress", g.dcrmAddress, "depositAddress", g.depositAddress,
+		"output", g.output, "dryRun", g.dryRun)
+}
+
+func (g *pairGenerator) initBridges() {
+	newBridge := func(gateway string, isSrc bool) *eth.Bridge {
+		bridge := eth.NewCrossChainBridge(isSrc)
+		bridge.ChainConfig = &tokens.ChainConfig{
+			BlockChain: "Ethereum",
+			NetID:      "custom",
+		}
+		bridge.GatewayConfig = &tokens.GatewayConfig{
+			APIAddress: []string{gateway},
+		}
+		bridge.VerifyChainID()
+		return bridge
+	}
+	g.srcBridge = newBridge(g.srcGateway, true)
+	g.dstBridge = newBridge(g.dstGateway, false)
+}
+
+func (g *pairGenerator) addCheck(name, detail string, err error) {
+	g.checks = append(g.checks, &genCheckResult{name: name, detail: detail, err: err})
+}
+
+func (g *pairGenerator) queryToken(bridge *eth.Bridge, token string, isSrc bool) *genTokenInfo {
+	prefix := "dest"
+	if isSrc {
+		prefix = "source"
+	}
+	if token == "" {
+		return &genTokenInfo{
+			ID:       g.srcSymbol,
+			Name:     g.srcName,
+			Symbol:   g.srcSymbol,
+			Decimals: 18,
+		}
+	}
+
+	// query code first, as verifying token of non contract address retries forever
+	code, err := bridge.GetCode(token)
+	if err == nil && len(code) == 0 {
+		err = fmt.Errorf("%v is not contract address", token)
+	}
+	g.addCheck(prefix+" contract code", token, err)
+	if err != nil {
+		return nil
+	}
+
+	info := &genTokenInfo{ContractAddress: common.HexToAddress(token).String()}
+	info.Name, err = bridge.GetErc20Name(token)
+	g.addCheck(prefix+" token name", info.Name, err)
+	info.Symbol, err = bridge.GetErc20Symbol(token)
+	g.addCheck(prefix+" token symbol", info.Symbol, err)
+	info.Decimals, err = bridge.GetErc20Decimals(token)
+	g.addCheck(prefix+" token decimals", strconv.Itoa(int(info.Decimals)), err)
+
+	impl, err := bridge.GetProxyImplementation(token)
+	if err == nil && impl != (common.Address{}) {
+		info.Implementation = impl.String()
+	}
+	g.addCheck(prefix+" proxy implementation", info.Implementation, err)
+
+	switch {
+	case !isSrc:
+		info.ID = info.Symbol
+	case info.Implementation != "":
+		info.ID = "ProxyERC20"
+		info.ContractCodeHash = common.Keccak256Hash(code).String()
+	default:
+		info.ID = "ERC20"
+	}
+	return info
+}
+
+func (g *pairGenerator) checkMinterRole() {
+	method, err := g.dstBridge.HasMinterRole(g.dstToken, g.dcrmAddress)
+	g.addCheck("dest minter role of dcrm address", method, err)
+}
+
+func (g *pairGenerator) loadPrice() (err error) {
+	if g.price > 0 {
+		return nil
+	}
+	g.price, err = tokens.LoadTokenPrice(g.priceCfg, g.srcBridge.SignerChainID, g.srcToken)
+	if err != nil || g.price == 0 {
+		g.price, err = tokens.LoadTokenPrice(g.priceCfg, g.dstBridge.SignerChainID, g.dstToken)
+	}
+	if err == nil && g.price <= 0 {
+		err = tokens.ErrMissTokenPrice
+	}
+	g.addCheck("token price", strconv.FormatFloat(g.price, 'f', -1, 64), err)
+	return err
+}
+
+// calcLimits calc limits of token (whole unit) from USD values and token price
+func (g *pairGenerator) calcLimits(info *genTokenInfo) {
+	info.MaximumSwap = formatTomlFloat(g.maxSwapUSD / g.price)
+	info.MinimumSwap = formatTomlFloat(g.minSwapUSD / g.price)
+	info.BigValueThreshold = formatTomlFloat(g.bigValueUSD / g.price)
+	info.SwapFeeRate = formatTomlFloat(g.swapFeeRate)
+	info.MaximumSwapFee = formatTomlFloat(g.maxSwapFeeUSD / g.price)
+	info.MinimumSwapFee = formatTomlFloat(g.minSwapFeeUSD / g.price)
+}
+
+// formatTomlFloat keep 6 significant digits and always output toml float
+func formatTomlFloat(value float64) string {
+	value, _ = strconv.ParseFloat(strconv.FormatFloat(value, 'g', 6, 64), 64)
+	str := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.Contains(str, ".") {
+		str += ".0"
+	}
+	return str
+}
+
+func (g *pairGenerator) genConfig() ([]byte, error) {
+	if err := g.loadPrice(); err != nil {
+		return nil, err
+	}
+	g.calcLimits(g.srcInfo)
+	g.calcLimits(g.dstInfo)
+
+	g.srcInfo.DepositAddress = g.depositAddress
+	description := fmt.Sprintf("cross chain bridge %v with %v", g.srcInfo.Symbol, g.dstInfo.Symbol)
+	g.srcInfo.Description = description
+	g.dstInfo.Description = description
+
+	type section struct {
+		Section string
+		*genTokenInfo
+	}
+	var buf bytes.Buffer
+	err := pairConfigTemplate.Execute(&buf, map[string]interface{}{
+		"PairID":       g.pairID,
+		"DiffDecimals": g.srcInfo.Decimals != g.dstInfo.Decimals,
+		"DcrmAddress":  g.dcrmAddress,
+		"DcrmPubkey":   g.dcrmPubkey,
+		"Tokens": []*section{
+			{Section: "SrcToken", genTokenInfo: g.srcInfo},
+			{Section: "DestToken", genTokenInfo: g.dstInfo},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// verifyConfig parse generated config and run checks of loading it
+func (g *pairGenerator) verifyConfig(content []byte) {
+	pairCfg := &tokens.TokenPairConfig{}
+	_, err := toml.Decode(string(content), pairCfg)
+	g.addCheck("decode pair config", "", err)
+	if err != nil {
+		return
+	}
+	err = pairCfg.CheckConfig()
+	g.addCheck("check pair config", "", err)
+	if err != nil {
+		return
+	}
+
+	verify := func(bridge *eth.Bridge, tokenCfg *tokens.TokenConfig, prefix string) {
+		names, errs := bridge.DryRunVerifyTokenConfig(tokenCfg)
+		for i, name := range names {
+			g.addCheck(prefix+" "+name, "", errs[i])
+		}
+	}
+	verify(g.srcBridge, pairCfg.SrcToken, "source")
+	verify(g.dstBridge, pairCfg.DestToken, "dest")
+}
+
+// printReport print result of every check, return true if all passed
+func (g *pairGenerator) printReport() (passed bool) {
+	passed = true
+	fmt.Printf("check report of token pair %v:\n", g.pairID)
+	for _, check := range g.checks {
+		status := "PASS"
+		if check.err != nil {
+			status = "FAIL"
+			passed = false
+		}
+		line := fmt.Sprintf("[%v] %v", status, check.name)
+		if check.detail != "" {
+			line += " (" + check.detail + ")"
+		}
+		if check.err != nil {
+			line += ": " + check.err.Error()
+		}
+		fmt.Println(line)
+	}
+	return passed
+}
@@ -28,6 +28,7 @@ func initApp() {
 		sendBtcCommand,
 		sendLtcCommand,
 		sendEthTxCommand,
+		genPairCommand,
 		utils.LicenseCommand,
 		utils.VersionCommand,
 	}
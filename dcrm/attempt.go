@@ -0,0 +1,112 @@
+package dcrm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+// GetEnodeID get enode ID from enode url (`enode://ID@IP:PORT`)
+func GetEnodeID(enode string) string {
+	enode = strings.ToLower(enode)
+	startIndex := strings.Index(enode, "enode://")
+	endIndex := strings.Index(enode, "@")
+	if startIndex != -1 && endIndex > startIndex {
+		return enode[startIndex+8 : endIndex]
+	}
+	return enode
+}
+
+// GetSignDisagreeMsgHash get msg hash of sign disagree report which is signed by oracle
+func GetSignDisagreeMsgHash(enodeID, keyID, reason string, timestamp int64) common.Hash {
+	msg := fmt.Sprintf("ReportSignDisagree:%v:%v:%v:%v", strings.ToLower(enodeID), keyID, timestamp, reason)
+	return common.Keccak256Hash([]byte(msg))
+}
+
+// SignWithDcrmUser sign msg hash with keystore of dcrm user (oracle's report)
+func SignWithDcrmUser(msgHash common.Hash) ([]byte, error) {
+	if defaultDcrmNode == nil || defaultDcrmNode.keyWrapper == nil {
+		return nil, errors.New("dcrm user keystore is not loaded")
+	}
+	return crypto.Sign(msgHash[:], defaultDcrmNode.keyWrapper.PrivateKey)
+}
+
+// getSwapKeysFromMsgContext get swap keys from msg context (batch sign has many)
+func getSwapKeysFromMsgContext(msgContext []string) (identifier string, swapKeys []string) {
+	if len(msgContext) == 0 {
+		return "", nil
+	}
+	var args tokens.BuildTxArgs
+	if err := json.Unmarshal([]byte(msgContext[0]), &args); err != nil {
+		return "", nil
+	}
+	argsList := args.Batch
+	if len(argsList) == 0 {
+		argsList = []*tokens.BuildTxArgs{&args}
+	}
+	for _, swapArgs := range argsList {
+		if swapArgs.SwapID == "" {
+			continue
+		}
+		swapKeys = append(swapKeys, mongodb.GetSwapKey(swapArgs.SwapID, swapArgs.PairID, swapArgs.Bind))
+	}
+	return args.Identifier, swapKeys
+}
+
+func newSignAttempt(keyID string, dcrmNode *NodeInfo, txdata *SignData, status string) *mongodb.MgoSignAttempt {
+	identifier, swapKeys := getSwapKeysFromMsgContext(txdata.MsgContext)
+	return &mongodb.MgoSignAttempt{
+		Key:        keyID,
+		GroupID:    txdata.GroupID,
+		SignType:   txdata.Keytype,
+		Initiator:  dcrmNode.dcrmUser.String(),
+		Identifier: identifier,
+		SwapKeys:   swapKeys,
+		MsgHash:    txdata.MsgHash,
+		Status:     status,
+		Timestamp:  time.Now().Unix(),
+	}
+}
+
+// recordPendingSignAttempt persist sign attempt as soon as it is started,
+// so disagree reasons reported before getting sign result are not rejected
+func recordPendingSignAttempt(keyID string, dcrmNode *NodeInfo, txdata *SignData) {
+	if !mongodb.HasClient() {
+		return
+	}
+	_ = mongodb.AddSignAttempt(newSignAttempt(keyID, dcrmNode, txdata, "Pending"))
+}
+
+// recordSignAttempt persist sign attempt and oracles' replies for inspection
+func recordSignAttempt(keyID string, dcrmNode *NodeInfo, txdata *SignData, signStatus *SignStatus, signErr error) {
+	if !mongodb.HasClient() {
+		return
+	}
+	attempt := newSignAttempt(keyID, dcrmNode, txdata, "Success")
+	if signStatus != nil {
+		if signStatus.Status != "" {
+			attempt.Status = signStatus.Status
+		}
+		for _, reply := range signStatus.AllReply {
+			attempt.Replies = append(attempt.Replies, &mongodb.MgoSignReply{
+				Enode:     GetEnodeID(reply.Enode),
+				Status:    reply.Status,
+				Timestamp: reply.TimeStamp,
+			})
+		}
+	}
+	if signErr != nil {
+		attempt.Error = signErr.Error()
+		if signStatus == nil {
+			attempt.Status = "Error"
+		}
+	}
+	_ = mongodb.AddSignAttempt(attempt)
+}
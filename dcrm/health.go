@@ -0,0 +1,235 @@
+package dcrm
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tools"
+)
+
+const (
+	minBackoffDuration = 10 * time.Second
+	maxBackoffDuration = 10 * time.Minute
+)
+
+var (
+	alertFailureCount = 5
+
+	healthLock  sync.Mutex
+	nodeHealth  = make(map[string]*HealthStat) // key is rpc address
+	groupHealth = make(map[string]*HealthStat) // key is sign group ID
+)
+
+// HealthStat health statistics of dcrm node or sign group
+type HealthStat struct {
+	SuccessCount     uint64
+	FailureCount     uint64
+	ConsecutiveFails uint64
+	SuccessRate      float64
+	AvgLatency       int64  // milliseconds of successful requests
+	LastError        string `json:",omitempty"`
+	LastSuccessTime  int64  `json:",omitempty"`
+	LastFailureTime  int64  `json:",omitempty"`
+	BackoffUntil     int64  `json:",omitempty"`
+
+	totalLatency time.Duration
+	alerted      bool
+}
+
+// Info dcrm health info
+type Info struct {
+	Nodes  map[string]*HealthStat
+	Groups map[string]*HealthStat
+}
+
+// GetDcrmInfo get health info of dcrm nodes and sign groups
+func GetDcrmInfo() *Info {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	info := &Info{
+		Nodes:  make(map[string]*HealthStat, len(nodeHealth)),
+		Groups: make(map[string]*HealthStat, len(groupHealth)),
+	}
+	for key, stat := range nodeHealth {
+		statCopy := *stat
+		info.Nodes[key] = &statCopy
+	}
+	for key, stat := range groupHealth {
+		statCopy := *stat
+		info.Groups[key] = &statCopy
+	}
+	return info
+}
+
+func getOrAddHealthStat(stats map[string]*HealthStat, key string) *HealthStat {
+	stat, exist := stats[key]
+	if !exist {
+		stat = &HealthStat{}
+		stats[key] = stat
+	}
+	return stat
+}
+
+// isHealthFailure disagree is decided by oracles and is not a health problem
+func isHealthFailure(err error) bool {
+	return err != nil && !errors.Is(err, ErrGetSignStatusHasDisagree)
+}
+
+func (s *HealthStat) record(latency time.Duration, err error) {
+	now := time.Now()
+	if err == nil {
+		s.SuccessCount++
+		s.ConsecutiveFails = 0
+		s.BackoffUntil = 0
+		s.LastSuccessTime = now.Unix()
+		s.totalLatency += latency
+		s.AvgLatency = (s.totalLatency / time.Duration(s.SuccessCount)).Milliseconds()
+		s.alerted = false
+	} else {
+		s.FailureCount++
+		s.ConsecutiveFails++
+		s.LastError = err.Error()
+		s.LastFailureTime = now.Unix()
+		backoff := maxBackoffDuration
+		if s.ConsecutiveFails <= 6 {
+			backoff = minBackoffDuration << (s.ConsecutiveFails - 1)
+		}
+		if backoff > maxBackoffDuration {
+			backoff = maxBackoffDuration
+		}
+		s.BackoffUntil = now.Add(backoff).Unix()
+	}
+	s.SuccessRate = float64(s.SuccessCount) / float64(s.SuccessCount+s.FailureCount)
+}
+
+func (s *HealthStat) isBackoff(now int64) bool {
+	return s != nil && s.BackoffUntil > now
+}
+
+func recordNodeHealth(rpcAddr string, latency time.Duration, err error) {
+	if err != nil && !isHealthFailure(err) {
+		return
+	}
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	stat := getOrAddHealthStat(nodeHealth, rpcAddr)
+	stat.record(latency, err)
+	if err != nil {
+		log.Warn("dcrm node failed", "rpcAddr", rpcAddr, "consecutiveFails", stat.ConsecutiveFails, "err", err)
+	}
+}
+
+func recordGroupHealth(groupID string, latency time.Duration, err error) {
+	if err != nil && !isHealthFailure(err) {
+		return
+	}
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	stat := getOrAddHealthStat(groupHealth, groupID)
+	stat.record(latency, err)
+	if err == nil {
+		return
+	}
+	log.Warn("dcrm sign group failed", "groupID", groupID, "consecutiveFails", stat.ConsecutiveFails, "backoffUntil", stat.BackoffUntil, "err", err)
+	if !stat.alerted && stat.ConsecutiveFails >= uint64(alertFailureCount) {
+		stat.alerted = true
+		go sendAlert("dcrm sign group is unusable", map[string]interface{}{
+			"groupID":          groupID,
+			"consecutiveFails": stat.ConsecutiveFails,
+			"lastError":        stat.LastError,
+		})
+	}
+}
+
+// sortInitiatorNodes sort initiator nodes to try by node health (the input is in config order).
+// nodes in backoff are skipped unless all nodes are in backoff.
+func sortInitiatorNodes(nodes []*NodeInfo) []*NodeInfo {
+	keys := make([]string, len(nodes))
+	for i, node := range nodes {
+		keys[i] = node.dcrmRPCAddress
+	}
+	order := sortByHealth(nodeHealth, keys)
+	sorted := make([]*NodeInfo, len(order))
+	for i, index := range order {
+		sorted[i] = nodes[index]
+	}
+	return sorted
+}
+
+// sortSignGroups sort sign groups to try by group health (the input is in random order).
+// groups in backoff are skipped unless all groups are in backoff.
+func sortSignGroups(groupIndexes []int64, signGroups []string) []int64 {
+	keys := make([]string, len(groupIndexes))
+	for i, groupIndex := range groupIndexes {
+		keys[i] = signGroups[groupIndex]
+	}
+	order := sortByHealth(groupHealth, keys)
+	sorted := make([]int64, len(order))
+	for i, index := range order {
+		sorted[i] = groupIndexes[index]
+	}
+	return sorted
+}
+
+// sortByHealth return indexes of keys sorted by health,
+// in order of success rate and then average latency.
+// keys in backoff are skipped unless all keys are in backoff,
+// in which case the one which will be recovered first is the first.
+func sortByHealth(stats map[string]*HealthStat, keys []string) []int {
+	now := time.Now().Unix()
+	healthLock.Lock()
+	defer healthLock.Unlock()
+
+	available := make([]int, 0, len(keys))
+	for i, key := range keys {
+		if !stats[key].isBackoff(now) {
+			available = append(available, i)
+		}
+	}
+	if len(available) == 0 {
+		sorted := make([]int, len(keys))
+		for i := range keys {
+			sorted[i] = i
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return stats[keys[sorted[i]]].BackoffUntil < stats[keys[sorted[j]]].BackoffUntil
+		})
+		return sorted
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		si, sj := stats[keys[available[i]]], stats[keys[available[j]]]
+		rateI, rateJ := getSuccessRate(si), getSuccessRate(sj)
+		if rateI != rateJ {
+			return rateI > rateJ
+		}
+		return getAvgLatency(si) < getAvgLatency(sj)
+	})
+	return available
+}
+
+// getSuccessRate new node or group is treated as healthy
+func getSuccessRate(s *HealthStat) float64 {
+	if s == nil || s.SuccessCount+s.FailureCount == 0 {
+		return 1
+	}
+	return s.SuccessRate
+}
+
+func getAvgLatency(s *HealthStat) int64 {
+	if s == nil {
+		return 0
+	}
+	return s.AvgLatency
+}
+
+func sendAlert(subject string, content map[string]interface{}) {
+	log.Error("[dcrm alert] "+subject, "content", content)
+	err := tools.PostAlert(params.GetAlertWebhook(), subject, content)
+	if err != nil {
+		log.Warn("post dcrm alert failed", "subject", subject, "err", err)
+	}
+}
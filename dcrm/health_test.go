@@ -0,0 +1,129 @@
+package dcrm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortSignGroups(t *testing.T) {
+	defer func() { groupHealth = make(map[string]*HealthStat) }()
+
+	now := time.Now().Unix()
+	signGroups := []string{"g0", "g1", "g2", "g3"}
+	tests := []struct {
+		name  string
+		stats map[string]*HealthStat
+		want  []int64
+	}{
+		{
+			name:  "new groups keep input order",
+			stats: map[string]*HealthStat{},
+			want:  []int64{2, 3, 0, 1},
+		},
+		{
+			name: "higher success rate first",
+			stats: map[string]*HealthStat{
+				"g2": {SuccessCount: 1, FailureCount: 1, SuccessRate: 0.5},
+				"g0": {SuccessCount: 3, FailureCount: 1, SuccessRate: 0.75},
+			},
+			want: []int64{3, 1, 0, 2},
+		},
+		{
+			name: "lower latency first if same success rate",
+			stats: map[string]*HealthStat{
+				"g2": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 300},
+				"g3": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 200},
+				"g0": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 100},
+				"g1": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 400},
+			},
+			want: []int64{0, 3, 2, 1},
+		},
+		{
+			name: "skip groups in backoff",
+			stats: map[string]*HealthStat{
+				"g2": {FailureCount: 1, ConsecutiveFails: 1, BackoffUntil: now + 100},
+				"g0": {FailureCount: 1, ConsecutiveFails: 1, BackoffUntil: now - 100},
+			},
+			want: []int64{3, 1, 0},
+		},
+		{
+			name: "all in backoff, recovered first is first",
+			stats: map[string]*HealthStat{
+				"g0": {BackoffUntil: now + 400},
+				"g1": {BackoffUntil: now + 300},
+				"g2": {BackoffUntil: now + 200},
+				"g3": {BackoffUntil: now + 100},
+			},
+			want: []int64{3, 2, 1, 0},
+		},
+	}
+	for _, test := range tests {
+		groupHealth = test.stats
+		got := sortSignGroups([]int64{2, 3, 0, 1}, signGroups)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: want %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestSortInitiatorNodes(t *testing.T) {
+	defer func() { nodeHealth = make(map[string]*HealthStat) }()
+
+	now := time.Now().Unix()
+	nodes := []*NodeInfo{
+		{dcrmRPCAddress: "node0"},
+		{dcrmRPCAddress: "node1"},
+		{dcrmRPCAddress: "node2"},
+	}
+	getAddresses := func(nodes []*NodeInfo) []string {
+		addrs := make([]string, len(nodes))
+		for i, node := range nodes {
+			addrs[i] = node.dcrmRPCAddress
+		}
+		return addrs
+	}
+	tests := []struct {
+		name  string
+		stats map[string]*HealthStat
+		want  []string
+	}{
+		{
+			name:  "new nodes keep config order",
+			stats: map[string]*HealthStat{},
+			want:  []string{"node0", "node1", "node2"},
+		},
+		{
+			name: "unhealthy node is tried later",
+			stats: map[string]*HealthStat{
+				"node0": {SuccessCount: 1, FailureCount: 3, SuccessRate: 0.25},
+				"node1": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 200},
+				"node2": {SuccessCount: 1, SuccessRate: 1, AvgLatency: 100},
+			},
+			want: []string{"node2", "node1", "node0"},
+		},
+		{
+			name: "skip nodes in backoff",
+			stats: map[string]*HealthStat{
+				"node0": {FailureCount: 1, ConsecutiveFails: 1, BackoffUntil: now + 100},
+			},
+			want: []string{"node1", "node2"},
+		},
+		{
+			name: "all in backoff, recovered first is first",
+			stats: map[string]*HealthStat{
+				"node0": {BackoffUntil: now + 300},
+				"node1": {BackoffUntil: now + 200},
+				"node2": {BackoffUntil: now + 100},
+			},
+			want: []string{"node2", "node1", "node0"},
+		},
+	}
+	for _, test := range tests {
+		nodeHealth = test.stats
+		got := getAddresses(sortInitiatorNodes(nodes))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: want %v, got %v", test.name, test.want, got)
+		}
+	}
+}
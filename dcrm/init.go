@@ -80,6 +80,10 @@ func Init(dcrmConfig *params.DcrmConfig, isServer bool) {
 
 	verifySignatureInAccept = dcrmConfig.VerifySignatureInAccept
 
+	if dcrmConfig.AlertFailureCount > 0 {
+		alertFailureCount = dcrmConfig.AlertFailureCount
+	}
+
 	setDcrmGroup(*dcrmConfig.GroupID, dcrmConfig.Mode, *dcrmConfig.NeededOracles, *dcrmConfig.TotalOracles)
 	setDefaultDcrmNodeInfo(initDcrmNodeInfo(dcrmConfig.DefaultNode, isServer))
 
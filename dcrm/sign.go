@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -75,35 +76,45 @@ func DoSign(signType, signPubkey string, msgHash, msgContext []string) (keyID st
 		return "", nil, errSignWithoutPublickey
 	}
 	for i := 0; i < retrySignLoop; i++ {
-		for _, dcrmNode := range allInitiatorNodes {
+		for _, dcrmNode := range sortInitiatorNodes(allInitiatorNodes) {
 			if err = pingDcrmNode(dcrmNode); err != nil {
+				recordNodeHealth(dcrmNode.dcrmRPCAddress, 0, err)
 				continue
 			}
-			signGroupsCount := int64(len(dcrmNode.signGroups))
-			// randomly pick first subgroup to sign
-			randIndex, _ := rand.Int(rand.Reader, big.NewInt(signGroupsCount))
-			startIndex := randIndex.Int64()
-			i := startIndex
-			for {
-				keyID, rsvs, err = doSignImpl(dcrmNode, i, signType, signPubkey, msgHash, msgContext)
+			for _, groupIndex := range selectSignGroups(dcrmNode) {
+				keyID, rsvs, err = doSignImpl(dcrmNode, groupIndex, signType, signPubkey, msgHash, msgContext)
 				if err == nil {
 					return keyID, rsvs, nil
 				}
-				i = (i + 1) % signGroupsCount
-				if i == startIndex {
-					break
-				}
 			}
 		}
 		time.Sleep(2 * time.Second)
 	}
 	log.Warn("dcrm DoSign failed", "msgHash", msgHash, "msgContext", msgContext, "signType", signType, "err", err)
+	if errors.Is(err, ErrGetSignStatusHasDisagree) {
+		return "", nil, err
+	}
 	return "", nil, errDoSignFailed
 }
 
+// selectSignGroups select sign groups to try in order of health
+func selectSignGroups(dcrmNode *NodeInfo) []int64 {
+	signGroupsCount := int64(len(dcrmNode.signGroups))
+	// randomly pick first subgroup to balance groups of same health
+	randIndex, _ := rand.Int(rand.Reader, big.NewInt(signGroupsCount))
+	startIndex := randIndex.Int64()
+	groupIndexes := make([]int64, signGroupsCount)
+	for i := int64(0); i < signGroupsCount; i++ {
+		groupIndexes[i] = (startIndex + i) % signGroupsCount
+	}
+	return sortSignGroups(groupIndexes, dcrmNode.signGroups)
+}
+
 func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	startTime := time.Now()
 	nonce, err := GetSignNonce(dcrmNode.dcrmUser.String(), dcrmNode.dcrmRPCAddress)
 	if err != nil {
+		recordNodeHealth(dcrmNode.dcrmRPCAddress, 0, err)
 		return "", nil, err
 	}
 	txdata := SignData{
@@ -139,11 +150,18 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signType, signPubkey s
 
 	rpcAddr := dcrmNode.dcrmRPCAddress
 	keyID, err = Sign(rawTX, rpcAddr)
+	recordNodeHealth(rpcAddr, time.Since(startTime), err)
 	if err != nil {
 		return "", nil, err
 	}
 
-	rsvs, err = getSignResult(keyID, rpcAddr)
+	recordPendingSignAttempt(keyID, dcrmNode, &txdata)
+
+	groupID := txdata.GroupID
+	startTime = time.Now()
+	rsvs, signStatus, err := getSignResult(keyID, rpcAddr)
+	recordGroupHealth(groupID, time.Since(startTime), err)
+	recordSignAttempt(keyID, dcrmNode, &txdata, signStatus, err)
 	if err != nil {
 		return "", nil, err
 	}
@@ -167,10 +185,12 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signType, signPubkey s
 
 // GetSignStatusByKeyID get sign status by keyID
 func GetSignStatusByKeyID(keyID string) (rsvs []string, err error) {
-	return getSignResult(keyID, defaultDcrmNode.dcrmRPCAddress)
+	rsvs, _, err = getSignResult(keyID, defaultDcrmNode.dcrmRPCAddress)
+	return rsvs, err
 }
 
-func getSignResult(keyID, rpcAddr string) (rsvs []string, err error) {
+// getSignResult get sign result and the last sign status (maybe nil)
+func getSignResult(keyID, rpcAddr string) (rsvs []string, lastStatus *SignStatus, err error) {
 	log.Info("start get sign status", "keyID", keyID)
 	var signStatus *SignStatus
 	i := 0
@@ -187,6 +207,9 @@ LOOP_GET_SIGN_STATUS:
 			break LOOP_GET_SIGN_STATUS
 		default:
 			signStatus, err = GetSignStatus(keyID, rpcAddr)
+			if signStatus != nil {
+				lastStatus = signStatus
+			}
 			if err == nil {
 				rsvs = signStatus.Rsv
 				break LOOP_GET_SIGN_STATUS
@@ -203,10 +226,16 @@ LOOP_GET_SIGN_STATUS:
 	}
 	if len(rsvs) == 0 || err != nil {
 		log.Info("get sign status failed", "keyID", keyID, "retryCount", i, "err", err)
-		return nil, errGetSignResultFailed
+		if errors.Is(err, ErrGetSignStatusHasDisagree) {
+			return nil, lastStatus, err
+		}
+		if err != nil {
+			return nil, lastStatus, fmt.Errorf("%w: %v", errGetSignResultFailed, err)
+		}
+		return nil, lastStatus, errGetSignResultFailed
 	}
 	log.Info("get sign status success", "keyID", keyID, "retryCount", i)
-	return rsvs, nil
+	return rsvs, lastStatus, nil
 }
 
 // BuildDcrmRawTx build dcrm raw tx
@@ -6,12 +6,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/btcsuite/btcd/txscript"
 	rpcjson "github.com/gorilla/rpc/v2/json2"
 )
@@ -20,8 +22,13 @@ var (
 	errNotBtcBridge      = newRPCError(-32096, "bridge is not btc")
 	errTokenPairNotExist = newRPCError(-32095, "token pair not exist")
 	errSwapCannotRetry   = newRPCError(-32094, "swap can not retry")
+	errNotEthBridge      = newRPCError(-32093, "bridge is not eth like")
+	errDcrmDisabled      = newRPCError(-32092, "dcrm is disabled")
+	errUtxoInfoNotReady  = newRPCError(-32091, "utxo info is not ready")
 
 	oraclesHeartbeats sync.Map // string -> int64 // key is enode
+
+	maxReportTimeDrift = int64(300) // seconds
 )
 
 func newRPCError(ec rpcjson.ErrorCode, message string) error {
@@ -52,18 +59,18 @@ func GetServerInfo() (*ServerInfo, error) {
 	}, nil
 }
 
-// UpdateOracleHeartbeat api
-func UpdateOracleHeartbeat(oracle string, timestamp int64) error {
-	var exist bool
+func isOtherOracle(oracle string) bool {
 	for _, enode := range dcrm.GetAllEnodes() {
 		if strings.EqualFold(oracle, enode) {
-			if !strings.EqualFold(oracle, dcrm.GetSelfEnode()) {
-				exist = true
-			}
-			break
+			return !strings.EqualFold(oracle, dcrm.GetSelfEnode())
 		}
 	}
-	if !exist {
+	return false
+}
+
+// UpdateOracleHeartbeat api
+func UpdateOracleHeartbeat(oracle string, timestamp int64) error {
+	if !isOtherOracle(oracle) {
 		return newRPCError(-32000, "wrong oracle info")
 	}
 	key := strings.ToLower(oracle)
@@ -97,6 +104,49 @@ func GetOraclesHeartbeat() map[string]string {
 	return result
 }
 
+// ReportSignDisagree api (the report must be signed by configed account of oracle)
+func ReportSignDisagree(oracle, keyID, reason string, timestamp int64, signature string) error {
+	if !isOtherOracle(oracle) {
+		return newRPCError(-32000, "wrong oracle info")
+	}
+	if keyID == "" {
+		return newRPCError(-32000, "empty keyID")
+	}
+	if len(reason) > 1000 {
+		return newRPCError(-32000, "too long reason")
+	}
+	nowTime := time.Now().Unix()
+	if timestamp < nowTime-maxReportTimeDrift || timestamp > nowTime+maxReportTimeDrift {
+		return newRPCError(-32000, "wrong report timestamp")
+	}
+	enodeID := dcrm.GetEnodeID(oracle)
+	account := params.GetServerConfig().GetOracleAccount(enodeID)
+	if account == "" {
+		return newRPCError(-32000, "oracle account is not configed")
+	}
+	msgHash := dcrm.GetSignDisagreeMsgHash(enodeID, keyID, reason, timestamp)
+	pubkey, err := crypto.SigToPub(msgHash[:], common.FromHex(signature))
+	if err != nil || !strings.EqualFold(crypto.PubkeyToAddress(*pubkey).String(), account) {
+		return newRPCError(-32000, "wrong report signature")
+	}
+	err = mongodb.AddSignDisagreeReason(keyID, enodeID, reason)
+	if err != nil {
+		return newRPCInternalError(err)
+	}
+	return nil
+}
+
+// GetOraclesDisagreeStat api (statistics of recent 7 days)
+func GetOraclesDisagreeStat() (map[string]*OracleDisagreeStat, error) {
+	septime := time.Now().Unix() - 7*24*3600
+	return mongodb.GetOraclesDisagreeStat(septime)
+}
+
+// GetSignAttempts api
+func GetSignAttempts(txid, pairID, bind *string) ([]*SignAttempt, error) {
+	return mongodb.FindSignAttempts(*txid, *pairID, *bind)
+}
+
 // GetStatusInfo api
 func GetStatusInfo(status string) (map[string]map[string]interface{}, error) {
 	return mongodb.GetStatusInfo(status)
@@ -135,6 +185,14 @@ func GetNonceInfo() (*SwapNonceInfo, error) {
 	}, nil
 }
 
+// GetDcrmInfo api
+func GetDcrmInfo() (*dcrm.Info, error) {
+	if !params.IsDcrmEnabled() {
+		return nil, errDcrmDisabled
+	}
+	return dcrm.GetDcrmInfo(), nil
+}
+
 // GetRawSwapin api
 func GetRawSwapin(txid, pairID, bindAddr *string) (*Swap, error) {
 	return mongodb.FindSwapin(*txid, *pairID, *bindAddr)
@@ -263,6 +321,31 @@ func Swapout(txid, pairID *string) (*PostResult, error) {
 	return swap(txid, pairID, false)
 }
 
+// PostSignedSwapout api
+func PostSignedSwapout(args *tokens.SignedSwapoutArgs) (string, error) {
+	log.Debug("[api] receive PostSignedSwapout", "pairID", args.PairID, "owner", args.Owner, "bind", args.Bind, "amount", args.Amount)
+	if err := basicCheckSwapRegister(tokens.DstBridge, args.PairID); err != nil {
+		return "", err
+	}
+	bridge, ok := tokens.DstBridge.(interface {
+		RelaySignedSwapout(args *tokens.SignedSwapoutArgs) (*tokens.TxSwapInfo, error)
+	})
+	if !ok {
+		return "", errNotEthBridge
+	}
+	swapInfo, err := bridge.RelaySignedSwapout(args)
+	if err != nil {
+		return "", newRPCError(-32099, "relay signed swapout failed! "+err.Error())
+	}
+	// the relayed tx is verified and registered again by `swap.Swapout` if this failed
+	err = addSwapToDatabase(swapInfo.Hash, tokens.SwapoutTx, swapInfo, nil)
+	if err != nil {
+		log.Warn("[api] add relayed swapout failed", "txid", swapInfo.Hash, "pairID", args.PairID, "err", err)
+	}
+	log.Info("[api] receive signed swapout", "txid", swapInfo.Hash, "pairID", args.PairID, "owner", args.Owner, "bind", args.Bind)
+	return swapInfo.Hash, nil
+}
+
 func basicCheckSwapRegister(bridge tokens.CrossChainBridge, pairIDStr string) error {
 	tokenCfg := bridge.GetTokenConfig(pairIDStr)
 	if tokenCfg == nil {
@@ -281,6 +364,14 @@ func swap(txid, pairID *string, isSwapin bool) (*PostResult, error) {
 	if err := basicCheckSwapRegister(bridge, pairIDStr); err != nil {
 		return nil, err
 	}
+	if verifier, ok := bridge.(depositsVerifier); ok && isSwapin {
+		err := registerDeposits(verifier, txidstr, pairIDStr)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("[api] receive swapin register", "txid", txidstr, "pairID", pairIDStr)
+		return &SuccessPostResult, nil
+	}
 	swapInfo, err := bridge.VerifyTransaction(pairIDStr, txidstr, true)
 	var txType tokens.SwapTxType
 	if isSwapin {
@@ -300,6 +391,35 @@ func swap(txid, pairID *string, isSwapin bool) (*PostResult, error) {
 	return &SuccessPostResult, nil
 }
 
+// depositsVerifier verify all deposits in tx (eg. batched or multicall deposits)
+type depositsVerifier interface {
+	VerifyTransactionDeposits(pairID, txHash string, allowUnstable bool) ([]*tokens.TxSwapInfo, []error)
+}
+
+// registerDeposits register every deposit in tx as its own swapin,
+// succeed if any deposit is registered
+func registerDeposits(verifier depositsVerifier, txid, pairID string) error {
+	swapInfos, verifyErrors := verifier.VerifyTransactionDeposits(pairID, txid, true)
+	var firstErr error
+	registered := false
+	for i, swapInfo := range swapInfos {
+		swapID := txid
+		if i > 0 {
+			swapID = swapInfo.GetSwapID()
+		}
+		err := addSwapToDatabase(swapID, tokens.SwapinTx, swapInfo, verifyErrors[i])
+		if err == nil {
+			registered = true
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if registered {
+		return nil
+	}
+	return firstErr
+}
+
 func addSwapToDatabase(txid string, txType tokens.SwapTxType, swapInfo *tokens.TxSwapInfo, verifyError error) (err error) {
 	if !tokens.ShouldRegisterSwapForError(verifyError) {
 		return newRPCError(-32099, "verify swap failed! "+verifyError.Error())
@@ -382,6 +502,56 @@ func calcP2shAddress(bindAddress string, addToDatabase bool) (*tokens.P2shAddres
 	}, nil
 }
 
+// RegisterDepositAddress api
+func RegisterDepositAddress(pairID, bindAddress string) (*tokens.DepositAddressInfo, error) {
+	return calcDepositAddress(pairID, bindAddress, true)
+}
+
+// GetDepositAddressInfo api
+func GetDepositAddressInfo(depositAddress string) (*tokens.DepositAddressInfo, error) {
+	result, err := mongodb.FindDepositAddressInfo(strings.ToLower(depositAddress))
+	if err != nil {
+		return nil, err
+	}
+	return calcDepositAddress(result.PairID, result.BindAddress, false)
+}
+
+func calcDepositAddress(pairID, bindAddress string, addToDatabase bool) (*tokens.DepositAddressInfo, error) {
+	bridge, ok := tokens.SrcBridge.(interface {
+		GetDepositAddress(pairID, bindAddress string) (*tokens.DepositAddressInfo, error)
+	})
+	if !ok {
+		return nil, errNotEthBridge
+	}
+	info, err := bridge.GetDepositAddress(pairID, bindAddress)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	if addToDatabase {
+		result, _ := mongodb.FindDepositAddressInfo(info.DepositAddress)
+		if result == nil {
+			_ = mongodb.AddDepositAddress(&mongodb.MgoDepositAddress{
+				Key:         info.DepositAddress,
+				PairID:      info.PairID,
+				BindAddress: info.BindAddress,
+			})
+		}
+	}
+	return info, nil
+}
+
+// GetUtxoInfo api (cached, updated by server job periodically)
+func GetUtxoInfo() (*tokens.UtxoInfo, error) {
+	if btc.BridgeInstance == nil {
+		return nil, errNotBtcBridge
+	}
+	info := btc.GetCachedUtxoInfo()
+	if info == nil {
+		return nil, errUtxoInfoNotReady
+	}
+	return info, nil
+}
+
 // P2shSwapin api
 func P2shSwapin(txid, bindAddr *string) (*PostResult, error) {
 	log.Debug("[api] receive P2shSwapin", "txid", *txid, "bindAddress", *bindAddr)
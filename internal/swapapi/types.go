@@ -20,6 +20,12 @@ type LatestScanInfo = mongodb.MgoLatestScanInfo
 // RegisteredAddress type alias
 type RegisteredAddress = mongodb.MgoRegisteredAddress
 
+// SignAttempt type alias
+type SignAttempt = mongodb.MgoSignAttempt
+
+// OracleDisagreeStat type alias
+type OracleDisagreeStat = mongodb.OracleDisagreeStat
+
 // ServerInfo server info
 type ServerInfo struct {
 	Identifier          string
@@ -239,3 +239,25 @@ func getSwapResultsTxStatus(bridge tokens.CrossChainBridge, res *MgoSwapResult)
 	}
 	return nil, ""
 }
+
+// --------------- admin log --------------------------------
+
+// AddAdminLog add admin log
+func AddAdminLog(caller, method string, params []string, result string) error {
+	if !HasClient() {
+		return nil
+	}
+	item := &MgoAdminLog{
+		Key:       newObjectID(),
+		Caller:    caller,
+		Method:    method,
+		Params:    params,
+		Result:    result,
+		Timestamp: time.Now().Unix(),
+	}
+	_, err := collAdminLog.InsertOne(clientCtx, item)
+	if err != nil {
+		log.Warn("mongodb add admin log failed", "caller", caller, "method", method, "params", params, "err", err)
+	}
+	return mgoError(err)
+}
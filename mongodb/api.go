@@ -179,7 +179,8 @@ func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, s
 	return mgoError(err)
 }
 
-// GetSwapKey txid + pairID + bind
+// GetSwapKey txid + pairID + bind,
+// where txid is swap ID with log index of batched deposit (see `tokens.GetSwapID`)
 func GetSwapKey(txid, pairID, bind string) string {
 	return strings.ToLower(txid + ":" + pairID + ":" + bind)
 }
@@ -304,6 +305,46 @@ func FindSwapResultsToReplace(status SwapStatus, septime int64, isSwapin bool) (
 	return result, mgoError(err)
 }
 
+// FindInflightSwapResults find swap results with nonce and not on chain (sorted by nonce)
+func FindInflightSwapResults(isSwapin bool, septime int64) ([]*MgoSwapResult, error) {
+	qtime := bson.M{"inittime": bson.M{"$gte": septime}}
+	qstatus := bson.M{"status": MatchTxNotStable}
+	qheight := bson.M{"swapheight": 0}
+	qnonce := bson.M{"swapnonce": bson.M{"$gt": 0}}
+	queries := []bson.M{qtime, qstatus, qheight, qnonce}
+	var collection *mongo.Collection
+	if isSwapin {
+		collection = collSwapinResult
+	} else {
+		collection = collSwapoutResult
+	}
+	limit := int64(1000)
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "swapnonce", Value: 1}},
+		Limit: &limit,
+	}
+	cur, err := collection.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 20)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// FindSwapinResultsBySwapTx find swapin results whose swap tx or old swap txs contains swapTx
+func FindSwapinResultsBySwapTx(swapTx string) ([]*MgoSwapResult, error) {
+	swapTx = strings.ToLower(swapTx)
+	query := bson.M{"$or": []bson.M{{"swaptx": swapTx}, {"oldswaptxs": swapTx}}}
+	cur, err := collSwapinResult.Find(clientCtx, query)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 1)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
 // --------------- swapout result --------------------------------
 
 // AddSwapoutResult add swapout result
@@ -641,6 +682,67 @@ func FindP2shAddresses(offset, limit int) ([]*MgoP2shAddress, error) {
 	return result, mgoError(err)
 }
 
+// ------------------ deposit address ------------------------
+
+// AddDepositAddress add deposit address
+func AddDepositAddress(ma *MgoDepositAddress) error {
+	ma.Timestamp = time.Now().Unix()
+	_, err := collDepositAddress.InsertOne(clientCtx, ma)
+	if err == nil {
+		log.Info("mongodb add deposit address", "key", ma.Key, "pairID", ma.PairID, "bindaddress", ma.BindAddress)
+	} else if !mongo.IsDuplicateKeyError(err) {
+		log.Error("mongodb add deposit address", "key", ma.Key, "pairID", ma.PairID, "bindaddress", ma.BindAddress, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindDepositAddress find deposit address through pairID and bind address
+func FindDepositAddress(pairID, bindAddress string) (*MgoDepositAddress, error) {
+	var result MgoDepositAddress
+	err := collDepositAddress.FindOne(clientCtx, bson.M{"pairid": pairID, "bindaddress": bindAddress}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindDepositAddressInfo find deposit address info through deposit address
+func FindDepositAddressInfo(depositAddress string) (*MgoDepositAddress, error) {
+	var result MgoDepositAddress
+	err := collDepositAddress.FindOne(clientCtx, bson.M{"_id": depositAddress}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// FindDepositAddresses find deposit addresses
+func FindDepositAddresses(offset, limit int) ([]*MgoDepositAddress, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cur, err := collDepositAddress.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoDepositAddress, 0, limit)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// AddSweepTx add sweep tx of deposit address
+func AddSweepTx(mt *MgoSweepTx) error {
+	mt.Timestamp = time.Now().Unix()
+	_, err := collSweepTx.InsertOne(clientCtx, mt)
+	if err == nil {
+		log.Info("mongodb add sweep tx", "txhash", mt.Key, "pairID", mt.PairID, "depositaddress", mt.DepositAddress, "nonce", mt.Nonce)
+	} else {
+		log.Error("mongodb add sweep tx", "txhash", mt.Key, "pairID", mt.PairID, "depositaddress", mt.DepositAddress, "nonce", mt.Nonce, "err", err)
+	}
+	return mgoError(err)
+}
+
 // ------------------ latest scan info ------------------------
 
 // UpdateLatestScanInfo update latest scan info
@@ -687,6 +789,63 @@ func FindLatestScanInfo(isSrc bool) (*MgoLatestScanInfo, error) {
 	return &result, mgoError(err)
 }
 
+// UpdateMappingTokenMonitorHeight update scanned block height of mapping token monitor
+func UpdateMappingTokenMonitorHeight(blockHeight uint64) error {
+	updates := bson.M{
+		"blockheight": blockHeight,
+		"timestamp":   time.Now().Unix(),
+	}
+	_, err := collLatestScanInfo.UpdateByID(clientCtx, keyOfMappingTokenMonitor, bson.M{"$set": updates}, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error("mongodb update mapping token monitor height failed", "updates", updates, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindMappingTokenMonitorHeight find scanned block height of mapping token monitor
+func FindMappingTokenMonitorHeight() (uint64, error) {
+	var result MgoLatestScanInfo
+	err := collLatestScanInfo.FindOne(clientCtx, bson.M{"_id": keyOfMappingTokenMonitor}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return result.BlockHeight, mgoError(err)
+}
+
+// AddMappingTokenPausedPairs add pairs paused by mapping token monitor
+func AddMappingTokenPausedPairs(pairIDs []string) error {
+	lowerPairIDs := make([]string, len(pairIDs))
+	for i, pairID := range pairIDs {
+		lowerPairIDs[i] = strings.ToLower(pairID)
+	}
+	updates := bson.M{"$addToSet": bson.M{"pausedpairs": bson.M{"$each": lowerPairIDs}}}
+	_, err := collLatestScanInfo.UpdateByID(clientCtx, keyOfMappingTokenMonitor, updates, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error("mongodb add mapping token paused pairs failed", "pairIDs", pairIDs, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RemoveMappingTokenPausedPair remove pair paused by mapping token monitor
+func RemoveMappingTokenPausedPair(pairID string) error {
+	updates := bson.M{"$pull": bson.M{"pausedpairs": strings.ToLower(pairID)}}
+	_, err := collLatestScanInfo.UpdateByID(clientCtx, keyOfMappingTokenMonitor, updates)
+	if err != nil {
+		log.Error("mongodb remove mapping token paused pair failed", "pairID", pairID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindMappingTokenPausedPairs find pairs paused by mapping token monitor
+func FindMappingTokenPausedPairs() ([]string, error) {
+	var result MgoLatestScanInfo
+	err := collLatestScanInfo.FindOne(clientCtx, bson.M{"_id": keyOfMappingTokenMonitor}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return result.PausedPairs, mgoError(err)
+}
+
 // ------------------------ register address ------------------------------
 
 // AddRegisteredAddress add register address
@@ -776,6 +935,11 @@ func FindLatestSwapNonce(key string) (*MgoLatestSwapNonce, error) {
 	return &result, nil
 }
 
+// FindLatestSwapNonceOf find latest swap nonce of address
+func FindLatestSwapNonceOf(address string, isSwapin bool) (*MgoLatestSwapNonce, error) {
+	return FindLatestSwapNonce(getSwapNonceKey(address, isSwapin))
+}
+
 // LoadAllSwapNonces load
 func LoadAllSwapNonces() (swapinNonces, swapoutNonces map[string]uint64) {
 	swapinNonces = make(map[string]uint64)
@@ -930,3 +1094,199 @@ func getStatusInfo(collection *mongo.Collection, pipeOption []bson.M) (map[strin
 	}
 	return statusInfo, nil
 }
+
+// AddSignFailure add sign failure event
+func AddSignFailure(keyID, signType, signPubkey string, msgHash, rsvs []string, reason string) error {
+	if !HasClient() {
+		return nil
+	}
+	item := &MgoSignFailure{
+		Key:        newObjectID(),
+		KeyID:      keyID,
+		SignType:   signType,
+		SignPubkey: signPubkey,
+		MsgHash:    msgHash,
+		Rsvs:       rsvs,
+		Reason:     reason,
+		Timestamp:  time.Now().Unix(),
+	}
+	_, err := collSignFailure.InsertOne(clientCtx, item)
+	if err == nil {
+		log.Info("mongodb add sign failure success", "keyID", keyID, "reason", reason)
+	} else {
+		log.Warn("mongodb add sign failure failed", "keyID", keyID, "reason", reason, "err", err)
+	}
+	return mgoError(err)
+}
+
+// AddSignAttempt add or update sign attempt (keep reported disagree reasons)
+func AddSignAttempt(item *MgoSignAttempt) error {
+	if !HasClient() {
+		return nil
+	}
+	updates := bson.M{
+		"groupid":    item.GroupID,
+		"signtype":   item.SignType,
+		"initiator":  item.Initiator,
+		"identifier": item.Identifier,
+		"swapkeys":   item.SwapKeys,
+		"msghash":    item.MsgHash,
+		"status":     item.Status,
+		"error":      item.Error,
+		"replies":    item.Replies,
+		"timestamp":  item.Timestamp,
+	}
+	_, err := collSignAttempt.UpdateByID(clientCtx, item.Key, bson.M{"$set": updates}, options.Update().SetUpsert(true))
+	if err == nil {
+		log.Info("mongodb add sign attempt success", "keyID", item.Key, "status", item.Status)
+	} else {
+		log.Warn("mongodb add sign attempt failed", "keyID", item.Key, "status", item.Status, "err", err)
+	}
+	return mgoError(err)
+}
+
+// AddSignDisagreeReason add disagree reason reported by oracle
+// to existing sign attempt (reports of unknown keyID are rejected)
+func AddSignDisagreeReason(keyID, enodeID, reason string) error {
+	updates := bson.M{"reasons." + strings.ToLower(enodeID): reason}
+	res, err := collSignAttempt.UpdateByID(clientCtx, keyID, bson.M{"$set": updates})
+	if err == nil && res.MatchedCount == 0 {
+		return ErrItemNotFound
+	}
+	if err == nil {
+		log.Info("mongodb add sign disagree reason success", "keyID", keyID, "enodeID", enodeID)
+	} else {
+		log.Warn("mongodb add sign disagree reason failed", "keyID", keyID, "enodeID", enodeID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindSignAttempts find sign attempts of swap
+func FindSignAttempts(txid, pairID, bind string) ([]*MgoSignAttempt, error) {
+	query := bson.M{"swapkeys": GetSwapKey(txid, pairID, bind)}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(100)
+	cur, err := collSignAttempt.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSignAttempt, 0, 10)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// GetOraclesDisagreeStat get count of replies and disagrees of every oracle since septime
+func GetOraclesDisagreeStat(septime int64) (map[string]*OracleDisagreeStat, error) {
+	pipeOption := []bson.M{
+		{"$match": bson.M{"timestamp": bson.M{"$gte": septime}}},
+		{"$unwind": "$replies"},
+		{"$group": bson.M{
+			"_id":   "$replies.enode",
+			"total": bson.M{"$sum": 1},
+			"disagree": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$toLower": "$replies.status"}, "disagree"}}, 1, 0},
+			}},
+		}},
+	}
+	ctx, cancel := context.WithDeadline(clientCtx, time.Now().Add(10*time.Second))
+	defer cancel()
+
+	cur, err := collSignAttempt.Aggregate(ctx, pipeOption)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	var items []struct {
+		Enode    string `bson:"_id"`
+		Total    uint64 `bson:"total"`
+		Disagree uint64 `bson:"disagree"`
+	}
+	err = cur.All(ctx, &items)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make(map[string]*OracleDisagreeStat, len(items))
+	for _, item := range items {
+		stat := &OracleDisagreeStat{
+			Total:    item.Total,
+			Disagree: item.Disagree,
+		}
+		if item.Total > 0 {
+			stat.DisagreeRate = float64(item.Disagree) / float64(item.Total)
+		}
+		result[item.Enode] = stat
+	}
+	return result, nil
+}
+
+// GetGasAirdropKey get gas airdrop key (one airdrop per bind address of chain)
+func GetGasAirdropKey(chain, bind string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", chain, bind))
+}
+
+// AddGasAirdrop add pending gas airdrop, return ErrItemIsDup if bind address of chain is already handled
+func AddGasAirdrop(chain, pairID, bind, swapTxID string) error {
+	item := &MgoGasAirdrop{
+		Key:       GetGasAirdropKey(chain, bind),
+		Chain:     strings.ToLower(chain),
+		PairID:    strings.ToLower(pairID),
+		Bind:      bind,
+		SwapTxID:  swapTxID,
+		Status:    GasAirdropPending,
+		Timestamp: time.Now().Unix(),
+	}
+	_, err := collGasAirdrop.InsertOne(clientCtx, item)
+	if err == nil {
+		log.Info("mongodb add gas airdrop success", "key", item.Key, "swaptxid", swapTxID)
+	} else if !mongo.IsDuplicateKeyError(err) {
+		log.Warn("mongodb add gas airdrop failed", "key", item.Key, "swaptxid", swapTxID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindGasAirdrops find gas airdrops by status
+func FindGasAirdrops(status string, limit int64) ([]*MgoGasAirdrop, error) {
+	query := bson.M{"status": status}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(limit)
+	cur, err := collGasAirdrop.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoGasAirdrop, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// UpdateGasAirdrop update gas airdrop status
+func UpdateGasAirdrop(key, status, txHash, memo string) error {
+	updates := bson.M{
+		"status": status,
+		"txhash": txHash,
+		"memo":   memo,
+	}
+	if status == GasAirdropSent {
+		updates["senttime"] = time.Now().Unix()
+	}
+	_, err := collGasAirdrop.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update gas airdrop success", "key", key, "status", status, "txhash", txHash, "memo", memo)
+	} else {
+		log.Warn("mongodb update gas airdrop failed", "key", key, "status", status, "txhash", txHash, "memo", memo, "err", err)
+	}
+	return mgoError(err)
+}
+
+// CountGasAirdropsSince count sent gas airdrops of pair since septime
+func CountGasAirdropsSince(pairID string, septime int64) (int64, error) {
+	query := bson.M{
+		"pairid":   strings.ToLower(pairID),
+		"status":   GasAirdropSent,
+		"senttime": bson.M{"$gte": septime},
+	}
+	count, err := collGasAirdrop.CountDocuments(clientCtx, query)
+	return count, mgoError(err)
+}
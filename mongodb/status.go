@@ -21,6 +21,7 @@ import (
 //                |- SwapInBlacklist   -> manual
 //                |- ManualMakeFail    -> manual
 //                |- TxNotSwapped -> |- TxProcessed (->MatchTxNotStable or ->MatchTxFailed)
+//                                   |- TxSimulateFailed -> retry reverify ---> TxNotStable
 // -----------------------------------------------
 // 2. swap result status change graph
 //
@@ -54,6 +55,7 @@ const (
 	SwapInBlacklist                         // 15
 	ManualMakeFail                          // 16
 	BindAddrIsContract                      // 17
+	TxSimulateFailed                        // 18
 
 	KeepStatus = 255
 	Reswapping = 256
@@ -66,7 +68,7 @@ func (status SwapStatus) CanManualMakeFail() bool {
 
 // CanRetry can retry
 func (status SwapStatus) CanRetry() bool {
-	return status == TxSenderNotRegistered
+	return status == TxSenderNotRegistered || status == TxSimulateFailed
 }
 
 // CanReverify can reverify
@@ -129,6 +131,8 @@ func (status SwapStatus) String() string {
 		return "ManualMakeFail"
 	case BindAddrIsContract:
 		return "BindAddrIsContract"
+	case TxSimulateFailed:
+		return "TxSimulateFailed"
 	case Reswapping:
 		return "Reswapping"
 	default:
@@ -13,15 +13,23 @@ const (
 	tbSwapinResults     string = "SwapinResults"
 	tbSwapoutResults    string = "SwapoutResults"
 	tbP2shAddresses     string = "P2shAddresses"
+	tbDepositAddresses  string = "DepositAddresses"
+	tbSweepTxs          string = "SweepTxs"
 	tbLatestScanInfo    string = "LatestScanInfo"
 	tbRegisteredAddress string = "RegisteredAddress"
 	tbBlacklist         string = "Blacklist"
 	tbLatestSwapNonces  string = "LatestSwapNonces"
 	tbSwapHistory       string = "SwapHistory"
 	tbUsedRValues       string = "UsedRValues"
+	tbAdminLogs         string = "AdminLogs"
+	tbSignFailures      string = "SignFailures"
+	tbSignAttempts      string = "SignAttempts"
+	tbGasAirdrops       string = "GasAirdrops"
 
 	keyOfSrcLatestScanInfo string = "srclatest"
 	keyOfDstLatestScanInfo string = "dstlatest"
+
+	keyOfMappingTokenMonitor string = "mappingtokenmonitor"
 )
 
 var (
@@ -32,12 +40,18 @@ var (
 	collSwapinResult      *mongo.Collection
 	collSwapoutResult     *mongo.Collection
 	collP2shAddress       *mongo.Collection
+	collDepositAddress    *mongo.Collection
+	collSweepTx           *mongo.Collection
 	collLatestScanInfo    *mongo.Collection
 	collRegisteredAddress *mongo.Collection
 	collBlacklist         *mongo.Collection
 	collLatestSwapNonces  *mongo.Collection
 	collSwapHistory       *mongo.Collection
 	collUsedRValue        *mongo.Collection
+	collAdminLog          *mongo.Collection
+	collSignFailure       *mongo.Collection
+	collSignAttempt       *mongo.Collection
+	collGasAirdrop        *mongo.Collection
 )
 
 func isSwapin(collection *mongo.Collection) bool {
@@ -50,14 +64,22 @@ func initCollections() {
 	initCollection(tbSwapins, &collSwapin, "inittime", "status")
 	initCollection(tbSwapouts, &collSwapout, "inittime", "status")
 	initCollection(tbSwapinResults, &collSwapinResult, "inittime", "status")
+	createOneIndex(collSwapinResult, "swaptx")
+	createOneIndex(collSwapinResult, "oldswaptxs")
 	initCollection(tbSwapoutResults, &collSwapoutResult, "inittime", "status")
 	initCollection(tbP2shAddresses, &collP2shAddress, "p2shaddress")
+	initCollection(tbDepositAddresses, &collDepositAddress, "pairid", "bindaddress")
+	initCollection(tbSweepTxs, &collSweepTx, "depositaddress", "timestamp")
 	initCollection(tbLatestScanInfo, &collLatestScanInfo)
 	initCollection(tbRegisteredAddress, &collRegisteredAddress)
 	initCollection(tbBlacklist, &collBlacklist)
 	initCollection(tbLatestSwapNonces, &collLatestSwapNonces, "address")
 	initCollection(tbSwapHistory, &collSwapHistory, "txid")
 	initCollection(tbUsedRValues, &collUsedRValue)
+	initCollection(tbAdminLogs, &collAdminLog, "timestamp")
+	initCollection(tbSignFailures, &collSignFailure, "keyid", "timestamp")
+	initCollection(tbSignAttempts, &collSignAttempt, "swapkeys", "timestamp")
+	initCollection(tbGasAirdrops, &collGasAirdrop, "status", "pairid", "senttime")
 }
 
 func initCollection(table string, collection **mongo.Collection, indexKey ...string) {
@@ -65,6 +65,25 @@ type MgoP2shAddress struct {
 	Timestamp   int64  `bson:"timestamp"`
 }
 
+// MgoDepositAddress key is the deposit address
+type MgoDepositAddress struct {
+	Key         string `bson:"_id"`
+	PairID      string `bson:"pairid"`
+	BindAddress string `bson:"bindaddress"`
+	Timestamp   int64  `bson:"timestamp"`
+}
+
+// MgoSweepTx key is the sweep tx hash
+type MgoSweepTx struct {
+	Key            string `bson:"_id"`
+	PairID         string `bson:"pairid"`
+	BindAddress    string `bson:"bindaddress"`
+	DepositAddress string `bson:"depositaddress"`
+	From           string `bson:"from"`
+	Nonce          uint64 `bson:"nonce"`
+	Timestamp      int64  `bson:"timestamp"`
+}
+
 // MgoRegisteredAddress key is address (in whitelist)
 type MgoRegisteredAddress struct {
 	Key       string `bson:"_id"`
@@ -73,9 +92,10 @@ type MgoRegisteredAddress struct {
 
 // MgoLatestScanInfo latest scan info
 type MgoLatestScanInfo struct {
-	Key         string `bson:"_id"`
-	BlockHeight uint64 `bson:"blockheight"`
-	Timestamp   int64  `bson:"timestamp"`
+	Key         string   `bson:"_id"`
+	BlockHeight uint64   `bson:"blockheight"`
+	Timestamp   int64    `bson:"timestamp"`
+	PausedPairs []string `bson:"pausedpairs,omitempty"` // pairs paused by mapping token monitor
 }
 
 // MgoBlackAccount key is address
@@ -111,6 +131,80 @@ type MgoUsedRValue struct {
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// MgoSignFailure sign failure event (eg. signature verify failed)
+type MgoSignFailure struct {
+	Key        primitive.ObjectID `bson:"_id"`
+	KeyID      string             `bson:"keyid"`
+	SignType   string             `bson:"signtype"`
+	SignPubkey string             `bson:"signpubkey"`
+	MsgHash    []string           `bson:"msghash"`
+	Rsvs       []string           `bson:"rsvs"`
+	Reason     string             `bson:"reason"`
+	Timestamp  int64              `bson:"timestamp"`
+}
+
+// MgoSignAttempt sign attempt with oracles' replies
+type MgoSignAttempt struct {
+	Key        string            `bson:"_id"`
+	GroupID    string            `bson:"groupid"`
+	SignType   string            `bson:"signtype"`
+	Initiator  string            `bson:"initiator"`
+	Identifier string            `bson:"identifier"`
+	SwapKeys   []string          `bson:"swapkeys"` // txid:pairid:bind
+	MsgHash    []string          `bson:"msghash"`
+	Status     string            `bson:"status"`
+	Error      string            `bson:"error,omitempty"`
+	Replies    []*MgoSignReply   `bson:"replies"`
+	Reasons    map[string]string `bson:"reasons,omitempty"` // key is enode ID
+	Timestamp  int64             `bson:"timestamp"`
+}
+
+// MgoSignReply oracle's reply of sign request
+type MgoSignReply struct {
+	Enode     string `bson:"enode"`
+	Status    string `bson:"status"`
+	Timestamp string `bson:"timestamp"`
+}
+
+// OracleDisagreeStat oracle disagree statistics
+type OracleDisagreeStat struct {
+	Total        uint64
+	Disagree     uint64
+	DisagreeRate float64
+}
+
+// gas airdrop status
+const (
+	GasAirdropPending = "pending"
+	GasAirdropSent    = "sent"
+	GasAirdropSkipped = "skipped"
+	GasAirdropFailed  = "failed"
+)
+
+// MgoGasAirdrop native gas airdrop to bind address (at most once per address of chain)
+type MgoGasAirdrop struct {
+	Key       string `bson:"_id"` // chain:bind
+	Chain     string `bson:"chain"`
+	PairID    string `bson:"pairid"`
+	Bind      string `bson:"bind"`
+	SwapTxID  string `bson:"swaptxid"`
+	Status    string `bson:"status"`
+	TxHash    string `bson:"txhash"`
+	Memo      string `bson:"memo"`
+	Timestamp int64  `bson:"timestamp"`
+	SentTime  int64  `bson:"senttime"`
+}
+
+// MgoAdminLog admin log (admin calls and automatic repairs)
+type MgoAdminLog struct {
+	Key       primitive.ObjectID `bson:"_id"`
+	Caller    string             `bson:"caller"`
+	Method    string             `bson:"method"`
+	Params    []string           `bson:"params"`
+	Result    string             `bson:"result"`
+	Timestamp int64              `bson:"timestamp"`
+}
+
 func newObjectID() primitive.ObjectID {
 	return primitive.NewObjectID()
 }
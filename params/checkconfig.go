@@ -2,10 +2,12 @@ package params
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
@@ -86,6 +88,26 @@ func (c *ServerConfig) CheckConfig() error {
 	if c.APIServer == nil {
 		return errors.New("server must config 'Server.APIServer'")
 	}
+	if c.BatchSignCount < 0 || c.BatchSignCount > MaxBatchSignCount {
+		return fmt.Errorf("server 'BatchSignCount' must be in range [0, %v]", MaxBatchSignCount)
+	}
+	if c.PreSignCount < 0 || c.PreSignCount > MaxBatchSignCount {
+		return fmt.Errorf("server 'PreSignCount' must be in range [0, %v]", MaxBatchSignCount)
+	}
+	if c.BatchSignCount > 1 && c.PreSignCount > 1 {
+		return errors.New("server can not enable both 'BatchSignCount' and 'PreSignCount'")
+	}
+	if c.NonceAuditInterval < 0 {
+		return errors.New("server 'NonceAuditInterval' must not be negative")
+	}
+	if c.MappingTokenMonitor != nil && c.MappingTokenMonitor.Interval < 0 {
+		return errors.New("server 'MappingTokenMonitor.Interval' must not be negative")
+	}
+	for enodeID, account := range c.OracleAccounts {
+		if !common.IsHexAddress(account) {
+			return fmt.Errorf("server 'OracleAccounts' has wrong account '%v' of enode ID '%v'", account, enodeID)
+		}
+	}
 	if IsTestMode() {
 		return nil
 	}
@@ -132,6 +154,9 @@ func (c *DcrmConfig) CheckConfig(isServer bool) (err error) {
 	if !(c.Mode == 0 || c.Mode == 1) {
 		return errors.New("dcrm must config 'Mode' to 0 (managed) or 1 (private)")
 	}
+	if c.AlertFailureCount < 0 {
+		return errors.New("dcrm 'AlertFailureCount' must not be negative")
+	}
 	if len(c.Initiators) == 0 {
 		return errors.New("dcrm must config 'Initiators'")
 	}
@@ -173,6 +198,18 @@ func (c *OracleConfig) CheckConfig() (err error) {
 	if c == nil {
 		return errors.New("oracle must config 'Oracle'")
 	}
+	if c.PolicyFile != "" {
+		err = LoadPolicyConfig(c.PolicyFile)
+		if err != nil {
+			return err
+		}
+	}
+	if c.IndependentVerify != nil {
+		err = c.IndependentVerify.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
 	if c.NoCheckServerConnection || IsTestMode() {
 		log.Info("oracle ignore check server connection")
 		return nil
@@ -196,6 +233,20 @@ func (c *OracleConfig) CheckConfig() (err error) {
 	return err
 }
 
+// CheckConfig check independent verify config
+func (c *IndependentVerifyConfig) CheckConfig() error {
+	if c.Quorum <= 0 {
+		return errors.New("independent verify must config positive 'Quorum'")
+	}
+	if len(c.SrcAPIAddress) < c.Quorum {
+		return errors.New("independent verify 'SrcAPIAddress' count is less than 'Quorum'")
+	}
+	if len(c.DestAPIAddress) < c.Quorum {
+		return errors.New("independent verify 'DestAPIAddress' count is less than 'Quorum'")
+	}
+	return nil
+}
+
 // CheckConfig extra config
 func (c *ExtraConfig) CheckConfig() (err error) {
 	if c.UsePendingBalance {
@@ -13,6 +13,9 @@ import (
 
 const (
 	defaultAPIPort = 11556
+
+	// MaxBatchSignCount max swaps in one batch sign request
+	MaxBatchSignCount = 20
 )
 
 var (
@@ -66,6 +69,43 @@ type ServerConfig struct {
 
 	SendTxLoopCount    int `toml:",omitempty" json:",omitempty"`
 	SendTxLoopInterval int `toml:",omitempty" json:",omitempty"`
+
+	// max swaps of eth like chain signed in one dcrm sign request
+	BatchSignCount int `toml:",omitempty" json:",omitempty"`
+	// max swaps of eth like chain signed concurrently with reserved nonces
+	PreSignCount int `toml:",omitempty" json:",omitempty"`
+
+	// interval seconds of auditing and repairing nonces of eth like chain (0 means disable)
+	NonceAuditInterval int64 `toml:",omitempty" json:",omitempty"`
+
+	// monitor mint, burn and role change events of mapping tokens on dest chain
+	MappingTokenMonitor *MappingTokenMonitorConfig `toml:",omitempty" json:",omitempty"`
+
+	// accounts of oracles which sign their reports (eg. sign disagree reasons),
+	// key is enode ID of oracle, value is its dcrm user account
+	OracleAccounts map[string]string `toml:",omitempty" json:",omitempty"`
+}
+
+// GetOracleAccount get account which signs reports of oracle
+func (c *ServerConfig) GetOracleAccount(enodeID string) string {
+	for id, account := range c.OracleAccounts {
+		if strings.EqualFold(id, enodeID) {
+			return account
+		}
+	}
+	return ""
+}
+
+// MappingTokenMonitorConfig mapping token monitor config
+type MappingTokenMonitorConfig struct {
+	// interval seconds of scanning (default 60)
+	Interval int64 `toml:",omitempty" json:",omitempty"`
+	// max blocks of one eth_getLogs query (default 1000)
+	BlockRange uint64 `toml:",omitempty" json:",omitempty"`
+	// start height if never scanned (default is the latest stable height)
+	StartHeight uint64 `toml:",omitempty" json:",omitempty"`
+	// disable swapin and swapout of the pair when alert
+	PauseOnAlert bool `toml:",omitempty" json:",omitempty"`
 }
 
 // DcrmConfig dcrm related config
@@ -77,6 +117,9 @@ type DcrmConfig struct {
 
 	VerifySignatureInAccept bool `toml:",omitempty" json:",omitempty"`
 
+	// alert when a sign group failed consecutively this times (default 5)
+	AlertFailureCount int `toml:",omitempty" json:",omitempty"`
+
 	GroupID       *string
 	NeededOracles *uint32
 	TotalOracles  *uint32
@@ -98,8 +141,23 @@ type DcrmNodeConfig struct {
 type OracleConfig struct {
 	ServerAPIAddress        string
 	GetAcceptListInterval   uint64
-	PendingInvalidAccept    bool `toml:",omitempty" json:",omitempty"`
-	NoCheckServerConnection bool `toml:",omitempty" json:",omitempty"`
+	PendingInvalidAccept    bool   `toml:",omitempty" json:",omitempty"`
+	NoCheckServerConnection bool   `toml:",omitempty" json:",omitempty"`
+	PolicyFile              string `toml:",omitempty" json:",omitempty"`
+
+	// prune accept records of swaps whose swap tx is stable beyond this blocks (0 means keep forever)
+	AcceptRecordRetentionBlocks uint64 `toml:",omitempty" json:",omitempty"`
+
+	IndependentVerify *IndependentVerifyConfig `toml:",omitempty" json:",omitempty"`
+}
+
+// IndependentVerifyConfig hardened oracle mode config.
+// oracle verifies swap tx through its own gateways (not the shared gateways)
+// and requires quorum of them agree on block hash, confirmations and swap info.
+type IndependentVerifyConfig struct {
+	SrcAPIAddress  []string
+	DestAPIAddress []string
+	Quorum         int
 }
 
 // APIServerConfig api service config
@@ -128,6 +186,9 @@ type ExtraConfig struct {
 	IsNullSwapoutNativeMemo  bool `toml:",omitempty" json:",omitempty"`
 	UsePendingBalance        bool `toml:",omitempty" json:",omitempty"`
 	CheckBindAddrIsContract  bool `toml:",omitempty" json:",omitempty"`
+
+	// post alert messages (eg. dcrm and mapping token alerts) to this webhook url if configed
+	AlertWebhook string `toml:",omitempty" json:"-"`
 }
 
 // GetAPIPort get api service port
@@ -149,6 +210,11 @@ func GetReplaceIdentifier() string {
 	return GetConfig().Identifier + ":replaceswap"
 }
 
+// GetBatchIdentifier get identifier of batch sign (to distiguish in dcrm accept)
+func GetBatchIdentifier() string {
+	return GetConfig().Identifier + ":batchswap"
+}
+
 // MustRegisterAccount flag
 func MustRegisterAccount() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().MustRegisterAccount
@@ -169,6 +235,14 @@ func IsNullSwapoutNativeMemo() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().IsNullSwapoutNativeMemo
 }
 
+// GetAlertWebhook get alert webhook url
+func GetAlertWebhook() string {
+	if GetExtraConfig() == nil {
+		return ""
+	}
+	return GetExtraConfig().AlertWebhook
+}
+
 // IsTestMode is test mode (get rid of business related components: MPC, DB, etc.)
 func IsTestMode() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().IsTestMode
@@ -220,6 +294,22 @@ func GetOracleConfig() *OracleConfig {
 	return GetConfig().Oracle
 }
 
+// GetIndependentVerifyConfig get independent verify config (oracle only)
+func GetIndependentVerifyConfig() *IndependentVerifyConfig {
+	if GetOracleConfig() == nil {
+		return nil
+	}
+	return GetOracleConfig().IndependentVerify
+}
+
+// GetAPIAddress get independent gateways of specified endpoint
+func (c *IndependentVerifyConfig) GetAPIAddress(isSrc bool) []string {
+	if isSrc {
+		return c.SrcAPIAddress
+	}
+	return c.DestAPIAddress
+}
+
 // GetExtraConfig get extra config
 func GetExtraConfig() *ExtraConfig {
 	return GetConfig().Extra
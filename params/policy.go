@@ -0,0 +1,171 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+var policyConfig *PolicyConfig
+
+// PolicyConfig oracle policy config (decode from toml file)
+// oracle evaluates the policy rules before agreeing a sign request
+type PolicyConfig struct {
+	Rules []*PolicyRule
+}
+
+// PolicyRule policy rule, zero value fields are not checked
+type PolicyRule struct {
+	Name     string
+	PairIDs  []string `toml:",omitempty" json:",omitempty"` // empty means all pairs
+	SwapType string   `toml:",omitempty" json:",omitempty"` // swapin, swapout or empty means both
+
+	// max value of single swap (whole unit of token)
+	MaxValue float64 `toml:",omitempty" json:",omitempty"`
+	// max sum value of agreed swaps of a pair in the latest time window
+	WindowSeconds  int64   `toml:",omitempty" json:",omitempty"`
+	MaxWindowValue float64 `toml:",omitempty" json:",omitempty"`
+	// deny if bind, from or tx to address is in this list
+	DenyAddresses []string `toml:",omitempty" json:",omitempty"`
+	// required confirmations of swap tx (may be above the server's)
+	MinConfirmations uint64 `toml:",omitempty" json:",omitempty"`
+	// allowed time ranges of day in UTC, eg. "08:00-20:00", "22:00-02:00"
+	AllowedTimeRanges []string `toml:",omitempty" json:",omitempty"`
+
+	pairIDs       map[string]struct{}
+	denyAddresses map[string]struct{}
+	timeRanges    [][2]int // minutes of day
+}
+
+// GetPolicyConfig get oracle policy config
+func GetPolicyConfig() *PolicyConfig {
+	return policyConfig
+}
+
+// LoadPolicyConfig load oracle policy config
+func LoadPolicyConfig(policyFile string) error {
+	if !common.FileExist(policyFile) {
+		return fmt.Errorf("policy file %v not exist", policyFile)
+	}
+	config := &PolicyConfig{}
+	if _, err := toml.DecodeFile(policyFile, config); err != nil {
+		return fmt.Errorf("decode policy file failed: %w", err)
+	}
+	if err := config.CheckConfig(); err != nil {
+		return err
+	}
+	policyConfig = config
+	log.Info("load policy config success", "policyFile", policyFile, "rules", len(config.Rules))
+	return nil
+}
+
+// CheckConfig check policy config
+func (c *PolicyConfig) CheckConfig() error {
+	names := make(map[string]struct{}, len(c.Rules))
+	for _, rule := range c.Rules {
+		if err := rule.CheckConfig(); err != nil {
+			return err
+		}
+		if _, exist := names[rule.Name]; exist {
+			return fmt.Errorf("duplicate policy rule name '%v'", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+	}
+	return nil
+}
+
+// CheckConfig check policy rule
+func (r *PolicyRule) CheckConfig() error {
+	if r.Name == "" {
+		return errors.New("policy rule must config 'Name'")
+	}
+	switch r.SwapType {
+	case "", "swapin", "swapout":
+	default:
+		return fmt.Errorf("policy rule '%v' has wrong 'SwapType' %v", r.Name, r.SwapType)
+	}
+	if r.MaxValue < 0 || r.MaxWindowValue < 0 || r.WindowSeconds < 0 {
+		return fmt.Errorf("policy rule '%v' has negative values", r.Name)
+	}
+	if (r.WindowSeconds > 0) != (r.MaxWindowValue > 0) {
+		return fmt.Errorf("policy rule '%v' must config both 'WindowSeconds' and 'MaxWindowValue'", r.Name)
+	}
+	if len(r.PairIDs) > 0 {
+		r.pairIDs = make(map[string]struct{}, len(r.PairIDs))
+		for _, pairID := range r.PairIDs {
+			r.pairIDs[strings.ToLower(pairID)] = struct{}{}
+		}
+	}
+	if len(r.DenyAddresses) > 0 {
+		r.denyAddresses = make(map[string]struct{}, len(r.DenyAddresses))
+		for _, addr := range r.DenyAddresses {
+			r.denyAddresses[strings.ToLower(addr)] = struct{}{}
+		}
+	}
+	r.timeRanges = make([][2]int, 0, len(r.AllowedTimeRanges))
+	for _, timeRange := range r.AllowedTimeRanges {
+		parts := strings.Split(timeRange, "-")
+		if len(parts) != 2 {
+			return fmt.Errorf("policy rule '%v' has wrong time range '%v'", r.Name, timeRange)
+		}
+		start, err1 := parseMinuteOfDay(parts[0])
+		end, err2 := parseMinuteOfDay(parts[1])
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("policy rule '%v' has wrong time range '%v'", r.Name, timeRange)
+		}
+		r.timeRanges = append(r.timeRanges, [2]int{start, end})
+	}
+	return nil
+}
+
+func parseMinuteOfDay(str string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(str))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// IsMatch is rule match pairID and swap type
+func (r *PolicyRule) IsMatch(pairID, swapType string) bool {
+	if r.pairIDs != nil {
+		if _, exist := r.pairIDs[strings.ToLower(pairID)]; !exist {
+			return false
+		}
+	}
+	return r.SwapType == "" || r.SwapType == swapType
+}
+
+// IsDeniedAddress is address in deny list
+func (r *PolicyRule) IsDeniedAddress(address string) bool {
+	if r.denyAddresses == nil || address == "" {
+		return false
+	}
+	_, exist := r.denyAddresses[strings.ToLower(address)]
+	return exist
+}
+
+// IsInAllowedTime is time in allowed time ranges
+func (r *PolicyRule) IsInAllowedTime(t time.Time) bool {
+	if len(r.timeRanges) == 0 {
+		return true
+	}
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	for _, timeRange := range r.timeRanges {
+		start, end := timeRange[0], timeRange[1]
+		if start <= end {
+			if minute >= start && minute < end {
+				return true
+			}
+		} else if minute >= start || minute < end { // cross midnight
+			return true
+		}
+	}
+	return false
+}
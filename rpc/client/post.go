@@ -88,6 +88,16 @@ func (err *jsonError) Error() string {
 	return fmt.Sprintf("json-rpc error %d, %s", err.Code, err.Message)
 }
 
+// ErrorCode returns the json-rpc error code
+func (err *jsonError) ErrorCode() int {
+	return err.Code
+}
+
+// ErrorData returns the json-rpc error data (eg. revert data of eth_call)
+func (err *jsonError) ErrorData() interface{} {
+	return err.Data
+}
+
 type jsonrpcResponse struct {
 	Version string          `json:"jsonrpc,omitempty"`
 	ID      json.RawMessage `json:"id,omitempty"`
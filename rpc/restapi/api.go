@@ -89,12 +89,34 @@ func TokenPairsInfoHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// OraclesDisagreeStatHandler handler
+func OraclesDisagreeStatHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetOraclesDisagreeStat()
+	writeResponse(w, res, err)
+}
+
+// GetSignAttemptsHandler handler
+func GetSignAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	txid := vars["txid"]
+	pairID := vars["pairid"]
+	bind := getBindParam(r)
+	res, err := swapapi.GetSignAttempts(&txid, &pairID, &bind)
+	writeResponse(w, res, err)
+}
+
 // NonceInfoHandler handler
 func NonceInfoHandler(w http.ResponseWriter, r *http.Request) {
 	res, err := swapapi.GetNonceInfo()
 	writeResponse(w, res, err)
 }
 
+// DcrmInfoHandler handler
+func DcrmInfoHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetDcrmInfo()
+	writeResponse(w, res, err)
+}
+
 func getBindParam(r *http.Request) string {
 	vals := r.URL.Query()
 	bindVals, exist := vals["bind"]
@@ -279,6 +301,29 @@ func GetP2shAddressInfo(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// UtxoInfoHandler handler
+func UtxoInfoHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetUtxoInfo()
+	writeResponse(w, res, err)
+}
+
+// RegisterDepositAddress handler
+func RegisterDepositAddress(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pairID := vars["pairid"]
+	address := vars["address"]
+	res, err := swapapi.RegisterDepositAddress(pairID, address)
+	writeResponse(w, res, err)
+}
+
+// GetDepositAddressInfo handler
+func GetDepositAddressInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+	res, err := swapapi.GetDepositAddressInfo(address)
+	writeResponse(w, res, err)
+}
+
 // RegisterAddress handler
 func RegisterAddress(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -51,7 +51,13 @@ func (s *RPCAPI) AdminCall(r *http.Request, rawTx, result *string) (err error) {
 		}
 	}
 	log.Info("admin call", "caller", senderAddress, "args", args, "result", result)
-	return doCall(args, result)
+	err = doCall(args, result)
+	callResult := *result
+	if err != nil {
+		callResult = err.Error()
+	}
+	_ = mongodb.AddAdminLog(senderAddress, args.Method, args.Params, callResult)
+	return err
 }
 
 func doCall(args *admin.CallArgs, result *string) error {
@@ -191,6 +197,11 @@ func maintain(args *admin.CallArgs, result *string) (err error) {
 			pairCfg.DestToken.DisableSwap = newDisableFlag
 		}
 
+		// pair paused by mapping token monitor is paused again after restart until fully opened
+		if !pairCfg.SrcToken.DisableSwap && !pairCfg.DestToken.DisableSwap {
+			_ = mongodb.RemoveMappingTokenPausedPair(pairID)
+		}
+
 		successPairs += " " + pairID
 	}
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
@@ -55,6 +56,34 @@ func (s *RPCAPI) GetOraclesHeartbeat(r *http.Request, args *RPCNullArgs, result
 	return nil
 }
 
+// SignDisagreeArgs sign disagree args
+type SignDisagreeArgs struct {
+	Enode     string `json:"enode"`
+	KeyID     string `json:"keyID"`
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+// ReportSignDisagree api
+func (s *RPCAPI) ReportSignDisagree(r *http.Request, args *SignDisagreeArgs, result *string) error {
+	err := swapapi.ReportSignDisagree(args.Enode, args.KeyID, args.Reason, args.Timestamp, args.Signature)
+	if err != nil {
+		return err
+	}
+	*result = "Success"
+	return nil
+}
+
+// GetOraclesDisagreeStat api
+func (s *RPCAPI) GetOraclesDisagreeStat(r *http.Request, args *RPCNullArgs, result *map[string]*swapapi.OracleDisagreeStat) error {
+	res, err := swapapi.GetOraclesDisagreeStat()
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
 // GetStatusInfo api
 func (s *RPCAPI) GetStatusInfo(r *http.Request, statuses *string, result *map[string]map[string]interface{}) error {
 	res, err := swapapi.GetStatusInfo(*statuses)
@@ -92,6 +121,15 @@ func (s *RPCAPI) GetNonceInfo(r *http.Request, args *RPCNullArgs, result *swapap
 	return err
 }
 
+// GetDcrmInfo api
+func (s *RPCAPI) GetDcrmInfo(r *http.Request, args *RPCNullArgs, result *dcrm.Info) error {
+	res, err := swapapi.GetDcrmInfo()
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // RPCTxAndPairIDArgs txid and pairID
 type RPCTxAndPairIDArgs struct {
 	TxID   string `json:"txid"`
@@ -125,6 +163,19 @@ func (s *RPCAPI) GetRawSwapin(r *http.Request, args *RPCTxAndPairIDArgs, result
 	return err
 }
 
+// GetSignAttempts api
+func (s *RPCAPI) GetSignAttempts(r *http.Request, args *RPCTxAndPairIDArgs, result *[]*swapapi.SignAttempt) error {
+	txid, pairID, bind, err := args.getTxAndPairID()
+	if err != nil {
+		return err
+	}
+	res, err := swapapi.GetSignAttempts(txid, pairID, bind)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
 // GetRawSwapinResult api
 func (s *RPCAPI) GetRawSwapinResult(r *http.Request, args *RPCTxAndPairIDArgs, result *swapapi.SwapResult) error {
 	txid, pairID, bind, err := args.getTxAndPairID()
@@ -272,6 +323,15 @@ func (s *RPCAPI) Swapout(r *http.Request, args *RPCTxAndPairIDArgs, result *swap
 	return err
 }
 
+// PostSignedSwapout api
+func (s *RPCAPI) PostSignedSwapout(r *http.Request, args *tokens.SignedSwapoutArgs, result *string) error {
+	res, err := swapapi.PostSignedSwapout(args)
+	if err == nil {
+		*result = res
+	}
+	return err
+}
+
 // IsValidSwapinBindAddress api
 func (s *RPCAPI) IsValidSwapinBindAddress(r *http.Request, address *string, result *bool) error {
 	*result = swapapi.IsValidSwapinBindAddress(address)
@@ -302,6 +362,39 @@ func (s *RPCAPI) GetP2shAddressInfo(r *http.Request, p2shAddress *string, result
 	return err
 }
 
+// GetUtxoInfo api
+func (s *RPCAPI) GetUtxoInfo(r *http.Request, args *RPCNullArgs, result *tokens.UtxoInfo) error {
+	res, err := swapapi.GetUtxoInfo()
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// RPCRegisterDepositAddressArgs args
+type RPCRegisterDepositAddressArgs struct {
+	PairID string `json:"pairid"`
+	Bind   string `json:"bind"`
+}
+
+// RegisterDepositAddress api
+func (s *RPCAPI) RegisterDepositAddress(r *http.Request, args *RPCRegisterDepositAddressArgs, result *tokens.DepositAddressInfo) error {
+	res, err := swapapi.RegisterDepositAddress(args.PairID, args.Bind)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetDepositAddressInfo api
+func (s *RPCAPI) GetDepositAddressInfo(r *http.Request, depositAddress *string, result *tokens.DepositAddressInfo) error {
+	res, err := swapapi.GetDepositAddressInfo(*depositAddress)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // GetLatestScanInfo api
 func (s *RPCAPI) GetLatestScanInfo(r *http.Request, isSrc *bool, result *swapapi.LatestScanInfo) error {
 	res, err := swapapi.GetLatestScanInfo(*isSrc)
@@ -100,8 +100,11 @@ func initRouter(r *mux.Router) {
 	r.HandleFunc("/serverinfo", restapi.ServerInfoHandler).Methods("GET")
 	r.HandleFunc("/versioninfo", restapi.VersionInfoHandler).Methods("GET")
 	r.HandleFunc("/oracleinfo", restapi.OracleInfoHandler).Methods("GET")
+	r.HandleFunc("/oracledisagree", restapi.OraclesDisagreeStatHandler).Methods("GET")
 	r.HandleFunc("/nonceinfo", restapi.NonceInfoHandler).Methods("GET")
 	r.HandleFunc("/statusinfo", restapi.StatusInfoHandler).Methods("GET")
+	r.HandleFunc("/utxoinfo", restapi.UtxoInfoHandler).Methods("GET")
+	r.HandleFunc("/dcrminfo", restapi.DcrmInfoHandler).Methods("GET")
 	r.HandleFunc("/pairinfo/{pairid}", restapi.TokenPairInfoHandler).Methods("GET")
 	r.HandleFunc("/pairsinfo/{pairids}", restapi.TokenPairsInfoHandler).Methods("GET")
 
@@ -116,12 +119,16 @@ func initRouter(r *mux.Router) {
 	r.HandleFunc("/swapout/{pairid}/{txid}/raw", restapi.GetRawSwapoutHandler).Methods("GET")
 	r.HandleFunc("/swapin/{pairid}/{txid}/rawresult", restapi.GetRawSwapinResultHandler).Methods("GET")
 	r.HandleFunc("/swapout/{pairid}/{txid}/rawresult", restapi.GetRawSwapoutResultHandler).Methods("GET")
+	r.HandleFunc("/signattempts/{pairid}/{txid}", restapi.GetSignAttemptsHandler).Methods("GET")
 	r.HandleFunc("/swapin/history/{pairid}/{address}", restapi.SwapinHistoryHandler).Methods("GET")
 	r.HandleFunc("/swapout/history/{pairid}/{address}", restapi.SwapoutHistoryHandler).Methods("GET")
 
 	r.HandleFunc("/p2sh/{address}", restapi.GetP2shAddressInfo).Methods("GET")
 	r.HandleFunc("/p2sh/bind/{address}", restapi.RegisterP2shAddress).Methods("POST")
 
+	r.HandleFunc("/deposit/{address}", restapi.GetDepositAddressInfo).Methods("GET")
+	r.HandleFunc("/deposit/bind/{pairid}/{address}", restapi.RegisterDepositAddress).Methods("POST")
+
 	r.HandleFunc("/registered/{address}", restapi.GetRegisteredAddress).Methods("GET")
 	r.HandleFunc("/register/{address}", restapi.RegisterAddress).Methods("POST")
 }
@@ -21,6 +21,8 @@ const (
 // common variables
 var (
 	AggregateIdentifier = "aggregate"
+	SweepIdentifier     = "sweep"
+	FillNonceIdentifier = "fillnonce"
 
 	SrcBridge CrossChainBridge
 	DstBridge CrossChainBridge
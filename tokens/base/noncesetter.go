@@ -2,6 +2,7 @@ package base
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
@@ -13,6 +14,10 @@ type NonceSetterBase struct {
 	*tokens.CrossChainBridgeBase
 	SwapinNonce  map[string]uint64
 	SwapoutNonce map[string]uint64
+
+	nonceLock        sync.Mutex
+	reservedNonces   map[string]uint64              // account -> next nonce to reserve
+	unreleasedNonces map[string]map[uint64]struct{} // account -> reserved and not released nonces
 }
 
 // NewNonceSetterBase new base nonce setter
@@ -21,6 +26,8 @@ func NewNonceSetterBase(isSrc bool) *NonceSetterBase {
 		CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc),
 		SwapinNonce:          make(map[string]uint64),
 		SwapoutNonce:         make(map[string]uint64),
+		reservedNonces:       make(map[string]uint64),
+		unreleasedNonces:     make(map[string]map[uint64]struct{}),
 	}
 }
 
@@ -28,6 +35,8 @@ func NewNonceSetterBase(isSrc bool) *NonceSetterBase {
 func (b *NonceSetterBase) SetNonce(pairID string, value uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
 	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
 	if b.IsSrcEndpoint() {
 		if b.SwapoutNonce[account] < value {
 			b.SwapoutNonce[account] = value
@@ -45,21 +54,74 @@ func (b *NonceSetterBase) SetNonce(pairID string, value uint64) {
 func (b *NonceSetterBase) AdjustNonce(pairID string, value uint64) (nonce uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
 	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
+	return b.adjustNonce(account, value)
+}
+
+func (b *NonceSetterBase) adjustNonce(account string, value uint64) (nonce uint64) {
 	nonce = value
 	if b.IsSrcEndpoint() {
-		if b.SwapoutNonce[account] > value {
+		if b.SwapoutNonce[account] > nonce {
 			nonce = b.SwapoutNonce[account]
 		}
 	} else {
-		if b.SwapinNonce[account] > value {
+		if b.SwapinNonce[account] > nonce {
 			nonce = b.SwapinNonce[account]
 		}
 	}
+	// skip nonces reserved for pre-signing
+	if b.reservedNonces[account] > nonce {
+		nonce = b.reservedNonces[account]
+	}
+	return nonce
+}
+
+// ReserveNonce reserve the next available nonce ahead (for pre-signing)
+func (b *NonceSetterBase) ReserveNonce(pairID string, value uint64) (nonce uint64) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
+	nonce = b.adjustNonce(account, value)
+	b.reservedNonces[account] = nonce + 1
+	if b.unreleasedNonces[account] == nil {
+		b.unreleasedNonces[account] = make(map[uint64]struct{})
+	}
+	b.unreleasedNonces[account][nonce] = struct{}{}
 	return nonce
 }
 
+// ReleaseNonce release reserved nonce when it is used or abandoned,
+// and it can be reserved again if it is the latest reserved one
+func (b *NonceSetterBase) ReleaseNonce(pairID string, nonce uint64) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
+	delete(b.unreleasedNonces[account], nonce)
+	if len(b.unreleasedNonces[account]) == 0 {
+		delete(b.unreleasedNonces, account)
+	}
+	if b.reservedNonces[account] == nonce+1 {
+		b.reservedNonces[account] = nonce
+	}
+}
+
+// IsNonceReserved is nonce reserved and not released
+func (b *NonceSetterBase) IsNonceReserved(pairID string, nonce uint64) bool {
+	tokenCfg := b.GetTokenConfig(pairID)
+	account := strings.ToLower(tokenCfg.DcrmAddress)
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
+	_, exist := b.unreleasedNonces[account][nonce]
+	return exist
+}
+
 // InitNonces init nonces
 func (b *NonceSetterBase) InitNonces(nonces map[string]uint64) {
+	b.nonceLock.Lock()
+	defer b.nonceLock.Unlock()
 	if b.IsSrcEndpoint() {
 		b.SwapoutNonce = nonces
 	} else {
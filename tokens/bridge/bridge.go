@@ -21,6 +21,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/tokens/ltc"
 	"github.com/anyswap/CrossChain-Bridge/tokens/okex"
 	"github.com/anyswap/CrossChain-Bridge/tokens/ripple"
+	_ "github.com/anyswap/CrossChain-Bridge/tokens/signer" // register signers
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
@@ -117,5 +118,9 @@ func InitCrossChainBridge(isServer bool) {
 
 	dcrm.Init(cfg.Dcrm, isServer)
 
+	if isServer {
+		tokens.InitSigners()
+	}
+
 	log.Info("Init bridge success", "isServer", isServer, "dcrmEnabled", !cfg.Dcrm.Disable)
 }
@@ -19,9 +19,27 @@ func (b *Bridge) ShouldAggregate(aggUtxoCount int, aggSumVal uint64) bool {
 	if aggSumVal >= cfgUtxoAggregateMinValue {
 		return true
 	}
+	if aggUtxoCount >= cfgUtxoAggregateMaxInputs {
+		return true
+	}
 	return false
 }
 
+// GetAggregateRelayFee get relay fee and whether it's in low fee window to aggregate
+func (b *Bridge) GetAggregateRelayFee() (relayFeePerKb int64, isLowFee bool, err error) {
+	relayFeePerKb, err = b.getRelayFeePerKb()
+	if err != nil {
+		return 0, false, err
+	}
+	isLowFee = cfgUtxoAggregateMaxFeePerKb == 0 || relayFeePerKb <= cfgUtxoAggregateMaxFeePerKb
+	return relayFeePerKb, isLowFee, nil
+}
+
+// IsAggregateDust is utxo value not enough to pay the fee of spending it
+func (b *Bridge) IsAggregateDust(value uint64, relayFeePerKb int64) bool {
+	return value*1000 <= uint64(redeemAggregateP2SHInputSize*relayFeePerKb)
+}
+
 // AggregateUtxos aggregate uxtos
 func (b *Bridge) AggregateUtxos(addrs []string, utxos []*electrs.ElectUtxo) (string, error) {
 	relayFee, err := b.getRelayFeePerKb()
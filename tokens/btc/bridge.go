@@ -8,6 +8,7 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 )
 
 const (
@@ -23,6 +24,7 @@ var PairID = "btc"
 type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	Inherit Inheritable
+	gateway electrs.Gateway
 }
 
 // NewCrossChainBridge new btc bridge
@@ -46,6 +48,7 @@ func (b *Bridge) SetInherit(inherit Inheritable) {
 func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
 	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
 	b.VerifyChainConfig()
+	b.gateway = electrs.NewGateway(b)
 	b.InitLatestBlockNumber()
 }
 
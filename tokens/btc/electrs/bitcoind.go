@@ -0,0 +1,449 @@
+package electrs
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/btcsuite/btcd/rpcclient"
+)
+
+const (
+	bitcoindBlockPageSize = 25 // same as electrs
+)
+
+// BitcoindGateway bitcoin core json rpc gateway (wallet-less)
+// get tx by hash requires the node running with `txindex=1`
+type BitcoindGateway struct {
+	bridge  tokens.CrossChainBridge
+	clients []*bitcoindClient
+
+	utxoCache     map[string]*bitcoindUtxoCacheEntry
+	utxoCacheLock sync.Mutex
+}
+
+type bitcoindClient struct {
+	*rpcclient.Client
+	Address string
+}
+
+// cache utxos scanned at the same tip height, as `scantxoutset` is expensive
+type bitcoindUtxoCacheEntry struct {
+	height uint64
+	utxos  []*ElectUtxo
+}
+
+type bitcoindScanResult struct {
+	Success  bool                   `json:"success"`
+	Height   uint64                 `json:"height"`
+	Unspents []*bitcoindScanUnspent `json:"unspents"`
+}
+
+type bitcoindScanUnspent struct {
+	Txid   string  `json:"txid"`
+	Vout   uint32  `json:"vout"`
+	Amount float64 `json:"amount"`
+	Height uint64  `json:"height"`
+}
+
+type bitcoindBlock struct {
+	Hash              string    `json:"hash"`
+	Confirmations     int64     `json:"confirmations"`
+	Size              uint32    `json:"size"`
+	Weight            uint32    `json:"weight"`
+	Height            uint32    `json:"height"`
+	Version           uint32    `json:"version"`
+	MerkleRoot        string    `json:"merkleroot"`
+	Time              uint32    `json:"time"`
+	Nonce             uint32    `json:"nonce"`
+	Bits              string    `json:"bits"`
+	Difficulty        float64   `json:"difficulty"`
+	PreviousBlockHash string    `json:"previousblockhash"`
+	NTx               uint32    `json:"nTx"`
+	Tx                []*CoreTx `json:"-"`
+	Txids             []string  `json:"-"`
+}
+
+type bitcoindBlockHeader struct {
+	Hash   string `json:"hash"`
+	Height uint64 `json:"height"`
+}
+
+type bitcoindSmartFee struct {
+	FeeRate *float64 `json:"feerate"`
+	Errors  []string `json:"errors"`
+}
+
+// NewBitcoindGateway new bitcoind gateway
+func NewBitcoindGateway(b tokens.CrossChainBridge) *BitcoindGateway {
+	g := &BitcoindGateway{
+		bridge:    b,
+		utxoCache: make(map[string]*bitcoindUtxoCacheEntry),
+	}
+	extra := b.GetGatewayConfig().GetBtcGatewayExtra()
+	if extra == nil || len(extra.CoreAPIs) == 0 {
+		log.Fatal("bitcoind gateway must config 'CoreAPIs'")
+	}
+	for _, args := range extra.CoreAPIs {
+		connCfg := &rpcclient.ConnConfig{
+			Host:         args.APIAddress,
+			User:         args.RPCUser,
+			Pass:         args.RPCPassword,
+			HTTPPostMode: true,            // Bitcoin core only supports HTTP POST mode
+			DisableTLS:   args.DisableTLS, // Bitcoin core does not provide TLS by default
+		}
+		client, err := rpcclient.New(connCfg, nil)
+		if err != nil {
+			log.Fatal("new bitcoind rpc client failed", "host", args.APIAddress, "err", err)
+		}
+		g.clients = append(g.clients, &bitcoindClient{Client: client, Address: args.APIAddress})
+	}
+	return g
+}
+
+func (c *bitcoindClient) call(result interface{}, method string, params ...interface{}) error {
+	rawParams := make([]json.RawMessage, 0, len(params))
+	for _, param := range params {
+		data, err := json.Marshal(param)
+		if err != nil {
+			return err
+		}
+		rawParams = append(rawParams, data)
+	}
+	data, err := c.RawRequest(method, rawParams)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (g *BitcoindGateway) call(result interface{}, method string, params ...interface{}) (err error) {
+	for _, client := range g.clients {
+		err = client.call(result, method, params...)
+		if err == nil {
+			return nil
+		}
+		log.Trace("call bitcoind failed", "host", client.Address, "method", method, "err", err)
+	}
+	return err
+}
+
+// GetLatestBlockNumberOf call getblockcount
+func (g *BitcoindGateway) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	for _, client := range g.clients {
+		if client.Address == apiAddress {
+			var result uint64
+			err := client.call(&result, "getblockcount")
+			return result, err
+		}
+	}
+	return 0, fmt.Errorf("bitcoind api address %v is not configed", apiAddress)
+}
+
+// GetLatestBlockNumber call getblockcount
+func (g *BitcoindGateway) GetLatestBlockNumber() (result uint64, err error) {
+	err = g.call(&result, "getblockcount")
+	return result, err
+}
+
+func (g *BitcoindGateway) getCoreTx(txHash string) (*CoreTx, error) {
+	var tx CoreTx
+	err := g.call(&tx, "getrawtransaction", txHash, true)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (g *BitcoindGateway) getBlockHeader(blockHash string) (*bitcoindBlockHeader, error) {
+	var header bitcoindBlockHeader
+	err := g.call(&header, "getblockheader", blockHash, true)
+	if err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// calc the 'latest' arg of ToElectTxStatus from the block header,
+// so the block height is exact even if the tip changes meanwhile
+func (g *BitcoindGateway) getLatestOfTx(tx *CoreTx) (uint64, error) {
+	if tx.Confirmations == 0 || tx.BlockHash == "" {
+		return 0, nil
+	}
+	header, err := g.getBlockHeader(tx.BlockHash)
+	if err != nil {
+		return 0, err
+	}
+	return header.Height + tx.Confirmations - 1, nil
+}
+
+// GetTransactionByHash call getrawtransaction (verbose)
+func (g *BitcoindGateway) GetTransactionByHash(txHash string) (*ElectTx, error) {
+	tx, err := g.getCoreTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = FillPrevouts(tx, g.getCoreTx); err != nil {
+		return nil, err
+	}
+	latest, err := g.getLatestOfTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.ToElectTx(latest), nil
+}
+
+// GetElectTransactionStatus call getrawtransaction (verbose)
+func (g *BitcoindGateway) GetElectTransactionStatus(txHash string) (*ElectTxStatus, error) {
+	tx, err := g.getCoreTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := g.getLatestOfTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.ToElectTxStatus(latest), nil
+}
+
+// FindUtxos call scantxoutset (confirmed only, big value first)
+// use `raw` descriptor of the output script to be independent of address format
+func (g *BitcoindGateway) FindUtxos(addr string) ([]*ElectUtxo, error) {
+	latest, err := g.GetLatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	g.utxoCacheLock.Lock()
+	defer g.utxoCacheLock.Unlock()
+
+	if entry, exist := g.utxoCache[addr]; exist && entry.height == latest {
+		return append([]*ElectUtxo{}, entry.utxos...), nil
+	}
+
+	script, err := GetAddressScript(g.bridge, addr)
+	if err != nil {
+		return nil, err
+	}
+	descriptor := fmt.Sprintf("raw(%v)", hex.EncodeToString(script))
+	var scanResult bitcoindScanResult
+	err = g.call(&scanResult, "scantxoutset", "start", []string{descriptor})
+	if err != nil {
+		return nil, err
+	}
+	if !scanResult.Success {
+		return nil, fmt.Errorf("scantxoutset of %v failed", addr)
+	}
+
+	result := make([]*ElectUtxo, 0, len(scanResult.Unspents))
+	for _, unspent := range scanResult.Unspents {
+		txid := unspent.Txid
+		vout := unspent.Vout
+		value := uint64(unspent.Amount*1e8 + 0.5)
+		height := unspent.Height
+		confirmed := true
+		result = append(result, &ElectUtxo{
+			Txid:   &txid,
+			Vout:   &vout,
+			Value:  &value,
+			Status: &ElectTxStatus{Confirmed: &confirmed, BlockHeight: &height},
+		})
+	}
+	sort.Sort(SortableElectUtxoSlice(result))
+
+	g.utxoCache[addr] = &bitcoindUtxoCacheEntry{height: latest, utxos: result}
+	return append([]*ElectUtxo{}, result...), nil
+}
+
+// GetPoolTxidList call getrawmempool
+func (g *BitcoindGateway) GetPoolTxidList() (result []string, err error) {
+	err = g.call(&result, "getrawmempool")
+	return result, err
+}
+
+// GetPoolTransactions call getrawmempool and filter txs paying to addr
+func (g *BitcoindGateway) GetPoolTransactions(addr string) ([]*ElectTx, error) {
+	script, err := GetAddressScript(g.bridge, addr)
+	if err != nil {
+		return nil, err
+	}
+	scriptHex := hex.EncodeToString(script)
+	txids, err := g.GetPoolTxidList()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ElectTx, 0)
+	for _, txid := range txids {
+		tx, errt := g.getCoreTx(txid)
+		if errt != nil {
+			continue // may be removed from pool meanwhile
+		}
+		for _, out := range tx.Vout {
+			if out.ScriptPubKey == nil || out.ScriptPubKey.Hex != scriptHex {
+				continue
+			}
+			if err = FillPrevouts(tx, g.getCoreTx); err != nil {
+				return nil, err
+			}
+			result = append(result, tx.ToElectTx(0))
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetTransactionHistory not supported (no address index)
+func (g *BitcoindGateway) GetTransactionHistory(addr, lastSeenTxid string) ([]*ElectTx, error) {
+	return nil, ErrNotSupportedByGateway
+}
+
+// GetOutspend call gettxout (include mempool)
+// only tell whether txout is spent, does not tell in which transaction it is spent
+func (g *BitcoindGateway) GetOutspend(txHash string, vout uint32) (*ElectOutspend, error) {
+	var txout json.RawMessage
+	err := g.call(&txout, "gettxout", txHash, vout, true)
+	if err != nil {
+		return nil, err
+	}
+	spent := len(txout) == 0 || string(txout) == "null"
+	return &ElectOutspend{Spent: &spent}, nil
+}
+
+// PostTransaction call sendrawtransaction
+func (g *BitcoindGateway) PostTransaction(txHex string) (txHash string, err error) {
+	var success bool
+	for _, client := range g.clients {
+		var hash0 string
+		err0 := client.call(&hash0, "sendrawtransaction", txHex)
+		if err0 == nil && !success {
+			success = true
+			txHash = hash0
+		} else if err0 != nil {
+			err = err0
+		}
+	}
+	return txHash, err
+}
+
+// GetBlockHash call getblockhash
+func (g *BitcoindGateway) GetBlockHash(height uint64) (blockHash string, err error) {
+	err = g.call(&blockHash, "getblockhash", height)
+	return blockHash, err
+}
+
+func (g *BitcoindGateway) getBlock(blockHash string, verbosity int) (*bitcoindBlock, error) {
+	var data json.RawMessage
+	err := g.call(&data, "getblock", blockHash, verbosity)
+	if err != nil {
+		return nil, err
+	}
+	var block bitcoindBlock
+	if err = json.Unmarshal(data, &block); err != nil {
+		return nil, err
+	}
+	switch verbosity {
+	case 1:
+		var txs struct {
+			Tx []string `json:"tx"`
+		}
+		err = json.Unmarshal(data, &txs)
+		block.Txids = txs.Tx
+	case 2:
+		var txs struct {
+			Tx []*CoreTx `json:"tx"`
+		}
+		err = json.Unmarshal(data, &txs)
+		block.Tx = txs.Tx
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetBlockTxids call getblock (verbosity 1)
+func (g *BitcoindGateway) GetBlockTxids(blockHash string) ([]string, error) {
+	block, err := g.getBlock(blockHash, 1)
+	if err != nil {
+		return nil, err
+	}
+	return block.Txids, nil
+}
+
+// GetBlock call getblock (verbosity 1)
+func (g *BitcoindGateway) GetBlock(blockHash string) (*ElectBlock, error) {
+	block, err := g.getBlock(blockHash, 1)
+	if err != nil {
+		return nil, err
+	}
+	eblock := &ElectBlock{
+		Hash:         &block.Hash,
+		Height:       &block.Height,
+		Version:      &block.Version,
+		Timestamp:    &block.Time,
+		TxCount:      &block.NTx,
+		Size:         &block.Size,
+		Weight:       &block.Weight,
+		MerkleRoot:   &block.MerkleRoot,
+		PreviousHash: &block.PreviousBlockHash,
+		Nonce:        &block.Nonce,
+		Bits:         new(uint32),
+		Difficulty:   new(uint64),
+	}
+	if _, err = fmt.Sscanf(block.Bits, "%x", eblock.Bits); err != nil {
+		log.Trace("parse block bits failed", "bits", block.Bits, "err", err)
+	}
+	*eblock.Difficulty = uint64(block.Difficulty)
+	return eblock, nil
+}
+
+// GetBlockTransactions call getblock (verbosity 2) (should startIndex%25 == 0)
+func (g *BitcoindGateway) GetBlockTransactions(blockHash string, startIndex uint32) ([]*ElectTx, error) {
+	block, err := g.getBlock(blockHash, 2)
+	if err != nil {
+		return nil, err
+	}
+	if block.Confirmations <= 0 {
+		return nil, errors.New("block is not in main chain")
+	}
+	if startIndex >= uint32(len(block.Tx)) {
+		return []*ElectTx{}, nil
+	}
+	endIndex := startIndex + bitcoindBlockPageSize
+	if endIndex > uint32(len(block.Tx)) {
+		endIndex = uint32(len(block.Tx))
+	}
+	latest := uint64(block.Height) + uint64(block.Confirmations) - 1
+	result := make([]*ElectTx, 0, endIndex-startIndex)
+	for _, tx := range block.Tx[startIndex:endIndex] {
+		tx.BlockHash = block.Hash
+		tx.Confirmations = uint64(block.Confirmations)
+		tx.BlockTime = uint64(block.Time)
+		if err = FillPrevouts(tx, g.getCoreTx); err != nil {
+			return nil, err
+		}
+		result = append(result, tx.ToElectTx(latest))
+	}
+	return result, nil
+}
+
+// EstimateFeePerKb call estimatesmartfee (BTC/kvB)
+func (g *BitcoindGateway) EstimateFeePerKb(blocks int) (int64, error) {
+	var result bitcoindSmartFee
+	err := g.call(&result, "estimatesmartfee", blocks)
+	if err != nil {
+		return 0, err
+	}
+	if result.FeeRate == nil {
+		return 0, fmt.Errorf("estimatesmartfee for %v blocks failed: %v", blocks, result.Errors)
+	}
+	return int64(*result.FeeRate * 1e8), nil
+}
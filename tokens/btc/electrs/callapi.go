@@ -1,4 +1,4 @@
-// Package electrs get or post RPC queries to electrs server.
+// Package electrs get or post RPC queries to electrs (or electrum) server.
 package electrs
 
 import (
@@ -48,6 +48,17 @@ func GetTransactionByHash(b tokens.CrossChainBridge, txHash string) (*ElectTx, e
 	return nil, err
 }
 
+// GetTransactionByHashOf call /tx/{txHash} of specified api address
+func GetTransactionByHashOf(apiAddress, txHash string) (*ElectTx, error) {
+	var result ElectTx
+	url := apiAddress + "/tx/" + txHash
+	err := client.RPCGet(&result, url)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetElectTransactionStatus call /tx/{txHash}/status
 func GetElectTransactionStatus(b tokens.CrossChainBridge, txHash string) (*ElectTxStatus, error) {
 	gateway := b.GetGatewayConfig()
@@ -63,6 +74,37 @@ func GetElectTransactionStatus(b tokens.CrossChainBridge, txHash string) (*Elect
 	return nil, err
 }
 
+// GetTransactionStatusOf call /tx/{txHash}/status of specified api address
+func GetTransactionStatusOf(apiAddress, txHash string) (*tokens.TxStatus, error) {
+	var result ElectTxStatus
+	url := apiAddress + "/tx/" + txHash + "/status"
+	err := client.RPCGet(&result, url)
+	if err != nil {
+		return nil, err
+	}
+	txStatus := &tokens.TxStatus{}
+	if result.Confirmed == nil || !*result.Confirmed {
+		return txStatus, nil
+	}
+	if result.BlockHash != nil {
+		txStatus.BlockHash = *result.BlockHash
+	}
+	if result.BlockTime != nil {
+		txStatus.BlockTime = *result.BlockTime
+	}
+	if result.BlockHeight != nil {
+		txStatus.BlockHeight = *result.BlockHeight
+		latest, err := GetLatestBlockNumberOf(apiAddress)
+		if err != nil {
+			return nil, err
+		}
+		if latest > txStatus.BlockHeight {
+			txStatus.Confirmations = latest - txStatus.BlockHeight
+		}
+	}
+	return txStatus, nil
+}
+
 // FindUtxos call /address/{add}/utxo (confirmed first, then big value first)
 func FindUtxos(b tokens.CrossChainBridge, addr string) (result []*ElectUtxo, err error) {
 	gateway := b.GetGatewayConfig()
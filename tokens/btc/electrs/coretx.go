@@ -0,0 +1,284 @@
+package electrs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// CoreTx verbose tx of bitcoin core compatible json rpc (getrawtransaction)
+type CoreTx struct {
+	Txid          string       `json:"txid"`
+	Hash          string       `json:"hash"`
+	Version       uint32       `json:"version"`
+	Size          uint32       `json:"size"`
+	Vsize         uint32       `json:"vsize"`
+	Weight        uint32       `json:"weight"`
+	Locktime      uint32       `json:"locktime"`
+	Vin           []*CoreTxIn  `json:"vin"`
+	Vout          []*CoreTxOut `json:"vout"`
+	Hex           string       `json:"hex,omitempty"`
+	BlockHash     string       `json:"blockhash,omitempty"`
+	Confirmations uint64       `json:"confirmations,omitempty"`
+	Time          uint64       `json:"time,omitempty"`
+	BlockTime     uint64       `json:"blocktime,omitempty"`
+}
+
+// CoreTxIn tx input of core tx
+type CoreTxIn struct {
+	Txid      string         `json:"txid"`
+	Vout      uint32         `json:"vout"`
+	Coinbase  string         `json:"coinbase,omitempty"`
+	ScriptSig *CoreScriptSig `json:"scriptSig,omitempty"`
+	Sequence  uint32         `json:"sequence"`
+	Prevout   *CoreTxOut     `json:"prevout,omitempty"`
+}
+
+// CoreScriptSig script sig of core tx input
+type CoreScriptSig struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+// CoreTxOut tx output of core tx
+type CoreTxOut struct {
+	Value        float64           `json:"value"`
+	N            uint32            `json:"n"`
+	ScriptPubKey *CoreScriptPubKey `json:"scriptPubKey"`
+}
+
+// CoreScriptPubKey script pubkey of core tx output
+type CoreScriptPubKey struct {
+	Asm       string   `json:"asm"`
+	Hex       string   `json:"hex"`
+	Type      string   `json:"type"`
+	Address   string   `json:"address,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// GetAddress get address of script pubkey
+func (spk *CoreScriptPubKey) GetAddress() string {
+	if spk.Address != "" {
+		return spk.Address
+	}
+	if len(spk.Addresses) == 1 {
+		return spk.Addresses[0]
+	}
+	return ""
+}
+
+// core script type to electrs script type
+var coreScriptTypes = map[string]string{
+	"pubkey":                "p2pk",
+	"pubkeyhash":            "p2pkh",
+	"scripthash":            "p2sh",
+	"nulldata":              "op_return",
+	"witness_v0_keyhash":    "v0_p2wpkh",
+	"witness_v0_scripthash": "v0_p2wsh",
+	"witness_v1_taproot":    "v1_p2tr",
+}
+
+// opcode value to name, prefer the name with lower lexical order of aliases
+var opcodeNames = func() map[byte]string {
+	names := make([]string, 0, len(txscript.OpcodeByName))
+	for name := range txscript.OpcodeByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make(map[byte]string, len(names))
+	for _, name := range names {
+		op := txscript.OpcodeByName[name]
+		if _, exist := result[op]; !exist {
+			result[op] = name
+		}
+	}
+	return result
+}()
+
+// ToElectAsm disassemble script in electrs asm format
+// eg. `OP_RETURN OP_PUSHBYTES_13 5377617049...`
+func ToElectAsm(script []byte) string {
+	parts := make([]string, 0)
+	for i := 0; i < len(script); {
+		op := script[i]
+		i++
+		var dataLen int
+		var name string
+		switch {
+		case op == txscript.OP_0:
+			parts = append(parts, "OP_0")
+			continue
+		case op < txscript.OP_PUSHDATA1:
+			dataLen = int(op)
+			name = fmt.Sprintf("OP_PUSHBYTES_%d", op)
+		case op == txscript.OP_PUSHDATA1 && i+1 <= len(script):
+			dataLen = int(script[i])
+			name = "OP_PUSHDATA1"
+			i++
+		case op == txscript.OP_PUSHDATA2 && i+2 <= len(script):
+			dataLen = int(script[i]) | int(script[i+1])<<8
+			name = "OP_PUSHDATA2"
+			i += 2
+		case op == txscript.OP_PUSHDATA4 && i+4 <= len(script):
+			dataLen = int(script[i]) | int(script[i+1])<<8 | int(script[i+2])<<16 | int(script[i+3])<<24
+			name = "OP_PUSHDATA4"
+			i += 4
+		case op == txscript.OP_1NEGATE:
+			parts = append(parts, "OP_PUSHNUM_NEG1")
+			continue
+		case op >= txscript.OP_1 && op <= txscript.OP_16:
+			parts = append(parts, fmt.Sprintf("OP_PUSHNUM_%d", op-txscript.OP_1+1))
+			continue
+		default:
+			if opName, exist := opcodeNames[op]; exist {
+				parts = append(parts, opName)
+			} else {
+				parts = append(parts, fmt.Sprintf("OP_UNKNOWN_%d", op))
+			}
+			continue
+		}
+		if dataLen < 0 || i+dataLen > len(script) {
+			parts = append(parts, name, "<push past end>")
+			break
+		}
+		parts = append(parts, name, hex.EncodeToString(script[i:i+dataLen]))
+		i += dataLen
+	}
+	return strings.Join(parts, " ")
+}
+
+// ToElectTxOut convert core tx output to electrs format
+func (out *CoreTxOut) ToElectTxOut() *ElectTxOut {
+	evout := &ElectTxOut{
+		Scriptpubkey:     new(string),
+		ScriptpubkeyAsm:  new(string),
+		ScriptpubkeyType: new(string),
+		Value:            new(uint64),
+	}
+	*evout.Value = uint64(out.Value*1e8 + 0.5)
+	*evout.ScriptpubkeyType = "unknown"
+	if out.ScriptPubKey == nil {
+		return evout
+	}
+	*evout.Scriptpubkey = out.ScriptPubKey.Hex
+	if script, err := hex.DecodeString(out.ScriptPubKey.Hex); err == nil {
+		*evout.ScriptpubkeyAsm = ToElectAsm(script)
+	}
+	if scriptType, exist := coreScriptTypes[out.ScriptPubKey.Type]; exist {
+		*evout.ScriptpubkeyType = scriptType
+	}
+	if address := out.ScriptPubKey.GetAddress(); address != "" {
+		evout.ScriptpubkeyAddress = &address
+	}
+	return evout
+}
+
+// ToElectTxin convert core tx input to electrs format
+func (in *CoreTxIn) ToElectTxin() *ElectTxin {
+	evin := &ElectTxin{
+		Txid:         new(string),
+		Vout:         new(uint32),
+		Scriptsig:    new(string),
+		ScriptsigAsm: new(string),
+		IsCoinbase:   new(bool),
+		Sequence:     new(uint32),
+	}
+	*evin.Txid = in.Txid
+	*evin.Vout = in.Vout
+	*evin.IsCoinbase = in.Coinbase != ""
+	*evin.Sequence = in.Sequence
+	if in.ScriptSig != nil {
+		*evin.Scriptsig = in.ScriptSig.Hex
+		if script, err := hex.DecodeString(in.ScriptSig.Hex); err == nil {
+			*evin.ScriptsigAsm = ToElectAsm(script)
+		}
+	}
+	if in.Prevout != nil {
+		evin.Prevout = in.Prevout.ToElectTxOut()
+	}
+	return evin
+}
+
+// ToElectTxStatus make electrs tx status from confirmations and latest height
+func (tx *CoreTx) ToElectTxStatus(latest uint64) *ElectTxStatus {
+	confirmed := tx.Confirmations > 0 && tx.BlockHash != ""
+	status := &ElectTxStatus{Confirmed: &confirmed}
+	if confirmed {
+		blockHash := tx.BlockHash
+		blockTime := tx.BlockTime
+		status.BlockHash = &blockHash
+		status.BlockTime = &blockTime
+		if latest+1 >= tx.Confirmations {
+			blockHeight := latest + 1 - tx.Confirmations
+			status.BlockHeight = &blockHeight
+		}
+	}
+	return status
+}
+
+// ToElectTx convert core tx to electrs format
+func (tx *CoreTx) ToElectTx(latest uint64) *ElectTx {
+	etx := &ElectTx{
+		Txid:     new(string),
+		Version:  new(uint32),
+		Locktime: new(uint32),
+		Size:     new(uint32),
+		Weight:   new(uint32),
+		Vin:      make([]*ElectTxin, 0, len(tx.Vin)),
+		Vout:     make([]*ElectTxOut, 0, len(tx.Vout)),
+		Status:   tx.ToElectTxStatus(latest),
+	}
+	*etx.Txid = tx.Txid
+	*etx.Version = tx.Version
+	*etx.Locktime = tx.Locktime
+	*etx.Size = tx.Size
+	*etx.Weight = tx.Weight
+	var inValue, outValue uint64
+	hasAllPrevouts := true
+	for _, in := range tx.Vin {
+		evin := in.ToElectTxin()
+		if evin.Prevout != nil {
+			inValue += *evin.Prevout.Value
+		} else {
+			hasAllPrevouts = false
+		}
+		etx.Vin = append(etx.Vin, evin)
+	}
+	for _, out := range tx.Vout {
+		evout := out.ToElectTxOut()
+		outValue += *evout.Value
+		etx.Vout = append(etx.Vout, evout)
+	}
+	if hasAllPrevouts && inValue >= outValue {
+		fee := inValue - outValue
+		etx.Fee = &fee
+	}
+	return etx
+}
+
+// FillPrevouts fill prevouts of inputs, as verbose tx of daemon does not contain them
+func FillPrevouts(tx *CoreTx, getTx func(txid string) (*CoreTx, error)) error {
+	prevTxs := make(map[string]*CoreTx)
+	for _, in := range tx.Vin {
+		if in.Coinbase != "" || in.Prevout != nil {
+			continue
+		}
+		prevTx, exist := prevTxs[in.Txid]
+		if !exist {
+			var err error
+			prevTx, err = getTx(in.Txid)
+			if err != nil {
+				return err
+			}
+			prevTxs[in.Txid] = prevTx
+		}
+		if in.Vout >= uint32(len(prevTx.Vout)) {
+			return fmt.Errorf("prevout (%v, %v) not exist", in.Txid, in.Vout)
+		}
+		in.Prevout = prevTx.Vout[in.Vout]
+	}
+	return nil
+}
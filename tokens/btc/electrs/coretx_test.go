@@ -0,0 +1,78 @@
+package electrs
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestToElectAsm(t *testing.T) {
+	tests := []struct {
+		script string
+		asm    string
+	}{
+		{
+			script: "6a0568656c6c6f",
+			asm:    "OP_RETURN OP_PUSHBYTES_5 68656c6c6f",
+		},
+		{
+			script: "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac",
+			asm:    "OP_DUP OP_HASH160 OP_PUSHBYTES_20 62e907b15cbf27d5425399ebf6f0fb50ebb88f18 OP_EQUALVERIFY OP_CHECKSIG",
+		},
+		{
+			script: "a914748284390f9e263a4b766a75d0633c50426eb87587",
+			asm:    "OP_HASH160 OP_PUSHBYTES_20 748284390f9e263a4b766a75d0633c50426eb875 OP_EQUAL",
+		},
+		{
+			script: "0014751e76e8199196d454941c45d1b3a323f1433bd6",
+			asm:    "OP_0 OP_PUSHBYTES_20 751e76e8199196d454941c45d1b3a323f1433bd6",
+		},
+	}
+	for i, test := range tests {
+		script, err := hex.DecodeString(test.script)
+		if err != nil {
+			t.Fatalf("test %v: decode script failed: %v", i, err)
+		}
+		if asm := ToElectAsm(script); asm != test.asm {
+			t.Errorf("test %v: asm mismatch, want '%v' have '%v'", i, test.asm, asm)
+		}
+	}
+}
+
+func TestGetAddressScriptHash(t *testing.T) {
+	// example in electrum protocol document
+	addr := "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	want := "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	have, err := GetAddressScriptHash(&testBridge{}, addr)
+	if err != nil {
+		t.Fatalf("get script hash of %v failed: %v", addr, err)
+	}
+	if have != want {
+		t.Errorf("script hash mismatch, want %v have %v", want, have)
+	}
+}
+
+// testBridge btc mainnet bridge for address decoding
+type testBridge struct {
+	tokens.CrossChainBridge
+}
+
+func (b *testBridge) GetPayToAddrScript(address string) ([]byte, error) {
+	addr, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	return txscript.PayToAddrScript(addr)
+}
+
+func TestGetAddressScriptWithoutDecoder(t *testing.T) {
+	_, err := GetAddressScript(nil, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	if !errors.Is(err, ErrNotSupportedByGateway) {
+		t.Errorf("want error %v, have %v", ErrNotSupportedByGateway, err)
+	}
+}
@@ -0,0 +1,490 @@
+package electrs
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const (
+	electrumClientName      = "CrossChain-Bridge"
+	electrumProtocolVersion = "1.4"
+	electrumDefaultTimeout  = 10 * time.Second
+	electrumHistoryPageSize = 25 // same as electrs
+)
+
+// ElectrumGateway electrum protocol (ElectrumX, Fulcrum) gateway
+// api address format is `tcp://host:port` or `ssl://host:port`
+type ElectrumGateway struct {
+	bridge    tokens.CrossChainBridge
+	tlsConfig *tls.Config
+	timeout   time.Duration
+}
+
+type electrumRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type electrumError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type electrumResponse struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *electrumError  `json:"error"`
+}
+
+type electrumHeader struct {
+	Height uint64 `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+type electrumUtxo struct {
+	TxHash string `json:"tx_hash"`
+	TxPos  uint32 `json:"tx_pos"`
+	Height int64  `json:"height"`
+	Value  uint64 `json:"value"`
+}
+
+type electrumHistory struct {
+	TxHash string `json:"tx_hash"`
+	Height int64  `json:"height"`
+}
+
+// NewElectrumGateway new electrum gateway
+func NewElectrumGateway(b tokens.CrossChainBridge) *ElectrumGateway {
+	g := &ElectrumGateway{
+		bridge:    b,
+		tlsConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+		timeout:   electrumDefaultTimeout,
+	}
+	if extra := b.GetGatewayConfig().GetBtcGatewayExtra(); extra != nil {
+		g.tlsConfig.InsecureSkipVerify = extra.InsecureSkipVerify //nolint:gosec // configurable for self-signed servers
+		if extra.RequestTimeout > 0 {
+			g.timeout = time.Duration(extra.RequestTimeout) * time.Second
+		}
+	}
+	return g
+}
+
+func (g *ElectrumGateway) dial(server string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: g.timeout}
+	switch {
+	case strings.HasPrefix(server, "ssl://"):
+		address := strings.TrimPrefix(server, "ssl://")
+		tlsConfig := g.tlsConfig.Clone()
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			tlsConfig.ServerName = host
+		}
+		return tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	case strings.HasPrefix(server, "tcp://"):
+		return dialer.Dial("tcp", strings.TrimPrefix(server, "tcp://"))
+	default:
+		return dialer.Dial("tcp", server)
+	}
+}
+
+func writeElectrumRequest(writer *bufio.Writer, id uint64, method string, params []interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	data, err := json.Marshal(&electrumRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// callServer call electrum method of the specified server
+// each call negotiates the protocol version first on a new connection
+func (g *ElectrumGateway) callServer(server string, result interface{}, method string, params ...interface{}) error {
+	conn, err := g.dial(server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(g.timeout))
+
+	const versionID, callID = 0, 1
+	writer := bufio.NewWriter(conn)
+	err = writeElectrumRequest(writer, versionID, "server.version", []interface{}{electrumClientName, electrumProtocolVersion})
+	if err != nil {
+		return err
+	}
+	err = writeElectrumRequest(writer, callID, method, params)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return err
+		}
+		var resp electrumResponse
+		if err = json.Unmarshal(line, &resp); err != nil {
+			return err
+		}
+		if resp.ID == nil { // notification
+			continue
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("electrum error %d, %s", resp.Error.Code, resp.Error.Message)
+		}
+		if *resp.ID != callID {
+			continue
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+	}
+}
+
+func (g *ElectrumGateway) call(result interface{}, method string, params ...interface{}) (err error) {
+	for _, server := range g.bridge.GetGatewayConfig().APIAddress {
+		err = g.callServer(server, result, method, params...)
+		if err == nil {
+			return nil
+		}
+		log.Trace("call electrum failed", "server", server, "method", method, "err", err)
+	}
+	if err == nil {
+		err = errors.New("no electrum server configed")
+	}
+	return err
+}
+
+// GetScriptHash get electrum script hash of output script
+func GetScriptHash(script []byte) string {
+	hash := sha256.Sum256(script)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:])
+}
+
+// payToAddrScriptGetter implemented by btc-like bridges (btc, ltc, colx)
+type payToAddrScriptGetter interface {
+	GetPayToAddrScript(address string) ([]byte, error)
+}
+
+// GetAddressScript get output script of address by bridge's own address decoder
+func GetAddressScript(b tokens.CrossChainBridge, addr string) ([]byte, error) {
+	getter, ok := b.(payToAddrScriptGetter)
+	if !ok {
+		return nil, fmt.Errorf("decode address %v failed: %w", addr, ErrNotSupportedByGateway)
+	}
+	return getter.GetPayToAddrScript(addr)
+}
+
+// GetAddressScriptHash get electrum script hash of address
+func GetAddressScriptHash(b tokens.CrossChainBridge, addr string) (string, error) {
+	script, err := GetAddressScript(b, addr)
+	if err != nil {
+		return "", err
+	}
+	return GetScriptHash(script), nil
+}
+
+// GetLatestBlockNumberOf call blockchain.headers.subscribe
+func (g *ElectrumGateway) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	var header electrumHeader
+	err := g.callServer(apiAddress, &header, "blockchain.headers.subscribe")
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
+// GetLatestBlockNumber call blockchain.headers.subscribe
+func (g *ElectrumGateway) GetLatestBlockNumber() (uint64, error) {
+	var header electrumHeader
+	err := g.call(&header, "blockchain.headers.subscribe")
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
+func (g *ElectrumGateway) getCoreTx(txHash string) (*CoreTx, error) {
+	var tx CoreTx
+	err := g.call(&tx, "blockchain.transaction.get", txHash, true)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (g *ElectrumGateway) getLatestIfConfirmed(tx *CoreTx) (latest uint64, err error) {
+	if tx.Confirmations == 0 {
+		return 0, nil
+	}
+	return g.GetLatestBlockNumber()
+}
+
+// GetTransactionByHash call blockchain.transaction.get (verbose)
+func (g *ElectrumGateway) GetTransactionByHash(txHash string) (*ElectTx, error) {
+	tx, err := g.getCoreTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = FillPrevouts(tx, g.getCoreTx); err != nil {
+		return nil, err
+	}
+	latest, err := g.getLatestIfConfirmed(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.ToElectTx(latest), nil
+}
+
+// GetElectTransactionStatus call blockchain.transaction.get (verbose)
+func (g *ElectrumGateway) GetElectTransactionStatus(txHash string) (*ElectTxStatus, error) {
+	tx, err := g.getCoreTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := g.getLatestIfConfirmed(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.ToElectTxStatus(latest), nil
+}
+
+func (g *ElectrumGateway) listUnspent(scriptHash string) (result []*electrumUtxo, err error) {
+	err = g.call(&result, "blockchain.scripthash.listunspent", scriptHash)
+	return result, err
+}
+
+// FindUtxos call blockchain.scripthash.listunspent (confirmed first, then big value first)
+func (g *ElectrumGateway) FindUtxos(addr string) ([]*ElectUtxo, error) {
+	scriptHash, err := GetAddressScriptHash(g.bridge, addr)
+	if err != nil {
+		return nil, err
+	}
+	unspents, err := g.listUnspent(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ElectUtxo, 0, len(unspents))
+	for _, unspent := range unspents {
+		txid := unspent.TxHash
+		vout := unspent.TxPos
+		value := unspent.Value
+		confirmed := unspent.Height > 0
+		status := &ElectTxStatus{Confirmed: &confirmed}
+		if confirmed {
+			height := uint64(unspent.Height)
+			status.BlockHeight = &height
+		}
+		result = append(result, &ElectUtxo{
+			Txid:   &txid,
+			Vout:   &vout,
+			Value:  &value,
+			Status: status,
+		})
+	}
+	sort.Sort(SortableElectUtxoSlice(result))
+	return result, nil
+}
+
+// GetPoolTxidList not supported
+func (g *ElectrumGateway) GetPoolTxidList() ([]string, error) {
+	return nil, ErrNotSupportedByGateway
+}
+
+func (g *ElectrumGateway) getTransactions(txids []string) ([]*ElectTx, error) {
+	result := make([]*ElectTx, 0, len(txids))
+	for _, txid := range txids {
+		tx, err := g.GetTransactionByHash(txid)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
+// GetPoolTransactions call blockchain.scripthash.get_mempool
+func (g *ElectrumGateway) GetPoolTransactions(addr string) ([]*ElectTx, error) {
+	scriptHash, err := GetAddressScriptHash(g.bridge, addr)
+	if err != nil {
+		return nil, err
+	}
+	var mempool []*electrumHistory
+	err = g.call(&mempool, "blockchain.scripthash.get_mempool", scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	txids := make([]string, 0, len(mempool))
+	for _, item := range mempool {
+		txids = append(txids, item.TxHash)
+	}
+	return g.getTransactions(txids)
+}
+
+// GetTransactionHistory call blockchain.scripthash.get_history
+// return confirmed txs newest first, paging after lastSeenTxid like electrs
+func (g *ElectrumGateway) GetTransactionHistory(addr, lastSeenTxid string) ([]*ElectTx, error) {
+	scriptHash, err := GetAddressScriptHash(g.bridge, addr)
+	if err != nil {
+		return nil, err
+	}
+	var history []*electrumHistory
+	err = g.call(&history, "blockchain.scripthash.get_history", scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	txids := make([]string, 0, electrumHistoryPageSize)
+	seen := lastSeenTxid == ""
+	for i := len(history) - 1; i >= 0 && len(txids) < electrumHistoryPageSize; i-- {
+		item := history[i]
+		if item.Height <= 0 {
+			continue
+		}
+		if !seen {
+			seen = item.TxHash == lastSeenTxid
+			continue
+		}
+		txids = append(txids, item.TxHash)
+	}
+	return g.getTransactions(txids)
+}
+
+// GetOutspend emulate by blockchain.scripthash.listunspent and get_history
+func (g *ElectrumGateway) GetOutspend(txHash string, vout uint32) (*ElectOutspend, error) {
+	tx, err := g.getCoreTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if vout >= uint32(len(tx.Vout)) || tx.Vout[vout].ScriptPubKey == nil {
+		return nil, fmt.Errorf("tx output (%v, %v) not exist", txHash, vout)
+	}
+	script, err := hex.DecodeString(tx.Vout[vout].ScriptPubKey.Hex)
+	if err != nil {
+		return nil, err
+	}
+	scriptHash := GetScriptHash(script)
+	unspents, err := g.listUnspent(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	spent := true
+	for _, unspent := range unspents {
+		if unspent.TxHash == txHash && unspent.TxPos == vout {
+			spent = false
+			return &ElectOutspend{Spent: &spent}, nil
+		}
+	}
+	outspend := &ElectOutspend{Spent: &spent}
+	var history []*electrumHistory
+	err = g.call(&history, "blockchain.scripthash.get_history", scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range history {
+		if item.TxHash == txHash {
+			continue
+		}
+		spendTx, errt := g.getCoreTx(item.TxHash)
+		if errt != nil {
+			return nil, errt
+		}
+		for i, in := range spendTx.Vin {
+			if in.Txid != txHash || in.Vout != vout {
+				continue
+			}
+			spendTxid := item.TxHash
+			vin := uint32(i)
+			outspend.Txid = &spendTxid
+			outspend.Vin = &vin
+			latest, errl := g.getLatestIfConfirmed(spendTx)
+			if errl != nil {
+				return nil, errl
+			}
+			outspend.Status = spendTx.ToElectTxStatus(latest)
+			return outspend, nil
+		}
+	}
+	return outspend, nil
+}
+
+// PostTransaction call blockchain.transaction.broadcast
+func (g *ElectrumGateway) PostTransaction(txHex string) (txHash string, err error) {
+	var success bool
+	for _, server := range g.bridge.GetGatewayConfig().APIAddress {
+		var hash0 string
+		err0 := g.callServer(server, &hash0, "blockchain.transaction.broadcast", txHex)
+		if err0 == nil && !success {
+			success = true
+			txHash = hash0
+		} else if err0 != nil {
+			err = err0
+		}
+	}
+	return txHash, err
+}
+
+// GetBlockHash call blockchain.block.header
+func (g *ElectrumGateway) GetBlockHash(height uint64) (string, error) {
+	var headerHex string
+	err := g.call(&headerHex, "blockchain.block.header", height)
+	if err != nil {
+		return "", err
+	}
+	header, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return "", err
+	}
+	return chainhash.DoubleHashH(header).String(), nil
+}
+
+// GetBlockTxids not supported
+func (g *ElectrumGateway) GetBlockTxids(blockHash string) ([]string, error) {
+	return nil, ErrNotSupportedByGateway
+}
+
+// GetBlock not supported
+func (g *ElectrumGateway) GetBlock(blockHash string) (*ElectBlock, error) {
+	return nil, ErrNotSupportedByGateway
+}
+
+// GetBlockTransactions not supported
+func (g *ElectrumGateway) GetBlockTransactions(blockHash string, startIndex uint32) ([]*ElectTx, error) {
+	return nil, ErrNotSupportedByGateway
+}
+
+// EstimateFeePerKb call blockchain.estimatefee (BTC/kB)
+func (g *ElectrumGateway) EstimateFeePerKb(blocks int) (int64, error) {
+	var result float64
+	err := g.call(&result, "blockchain.estimatefee", blocks)
+	if err != nil {
+		return 0, err
+	}
+	if result <= 0 {
+		return 0, fmt.Errorf("electrum can not estimate fee for %v blocks", blocks)
+	}
+	return int64(result * 1e8), nil
+}
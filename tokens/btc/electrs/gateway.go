@@ -0,0 +1,160 @@
+package electrs
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/btcsuite/btcutil"
+)
+
+// gateway types
+const (
+	ElectrsGatewayType  = "electrs"
+	ElectrumGatewayType = "electrum"
+	BitcoindGatewayType = "bitcoind"
+)
+
+// ErrNotSupportedByGateway not supported by gateway
+var ErrNotSupportedByGateway = errors.New("not supported by this gateway type")
+
+// Gateway interface of btc-like blockchain gateway
+type Gateway interface {
+	GetLatestBlockNumberOf(apiAddress string) (uint64, error)
+	GetLatestBlockNumber() (uint64, error)
+	GetTransactionByHash(txHash string) (*ElectTx, error)
+	GetElectTransactionStatus(txHash string) (*ElectTxStatus, error)
+	FindUtxos(addr string) ([]*ElectUtxo, error)
+	GetPoolTxidList() ([]string, error)
+	GetPoolTransactions(addr string) ([]*ElectTx, error)
+	GetTransactionHistory(addr, lastSeenTxid string) ([]*ElectTx, error)
+	GetOutspend(txHash string, vout uint32) (*ElectOutspend, error)
+	PostTransaction(txHex string) (txHash string, err error)
+	GetBlockHash(height uint64) (string, error)
+	GetBlockTxids(blockHash string) ([]string, error)
+	GetBlock(blockHash string) (*ElectBlock, error)
+	GetBlockTransactions(blockHash string, startIndex uint32) ([]*ElectTx, error)
+	EstimateFeePerKb(blocks int) (int64, error)
+}
+
+// GetGatewayType get gateway type from gateway config
+func GetGatewayType(gatewayCfg *tokens.GatewayConfig) string {
+	extra := gatewayCfg.GetBtcGatewayExtra()
+	if extra == nil || extra.GatewayType == "" {
+		return ElectrsGatewayType
+	}
+	return strings.ToLower(extra.GatewayType)
+}
+
+// NewGateway new gateway of the type specified in gateway config
+func NewGateway(b tokens.CrossChainBridge) Gateway {
+	gatewayType := GetGatewayType(b.GetGatewayConfig())
+	switch gatewayType {
+	case ElectrsGatewayType:
+		return &RestGateway{bridge: b}
+	case ElectrumGatewayType:
+		return NewElectrumGateway(b)
+	case BitcoindGatewayType:
+		return NewBitcoindGateway(b)
+	default:
+		log.Fatal("unsupported btc gateway type", "type", gatewayType)
+	}
+	return nil
+}
+
+// RestGateway electrs REST API gateway
+type RestGateway struct {
+	bridge tokens.CrossChainBridge
+}
+
+// getRestAddress get address used in electrs REST API,
+// which only accepts btc format address (eg. for ltc).
+func (g *RestGateway) getRestAddress(addr string) string {
+	converter, ok := g.bridge.(interface {
+		ConvertLTCAddress(addr, net string) (btcutil.Address, error)
+	})
+	if !ok {
+		return addr
+	}
+	btcaddr, err := converter.ConvertLTCAddress(addr, "")
+	if err != nil {
+		return addr
+	}
+	return btcaddr.String()
+}
+
+// GetLatestBlockNumberOf impl
+func (g *RestGateway) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	return GetLatestBlockNumberOf(apiAddress)
+}
+
+// GetLatestBlockNumber impl
+func (g *RestGateway) GetLatestBlockNumber() (uint64, error) {
+	return GetLatestBlockNumber(g.bridge)
+}
+
+// GetTransactionByHash impl
+func (g *RestGateway) GetTransactionByHash(txHash string) (*ElectTx, error) {
+	return GetTransactionByHash(g.bridge, txHash)
+}
+
+// GetElectTransactionStatus impl
+func (g *RestGateway) GetElectTransactionStatus(txHash string) (*ElectTxStatus, error) {
+	return GetElectTransactionStatus(g.bridge, txHash)
+}
+
+// FindUtxos impl
+func (g *RestGateway) FindUtxos(addr string) ([]*ElectUtxo, error) {
+	return FindUtxos(g.bridge, g.getRestAddress(addr))
+}
+
+// GetPoolTxidList impl
+func (g *RestGateway) GetPoolTxidList() ([]string, error) {
+	return GetPoolTxidList(g.bridge)
+}
+
+// GetPoolTransactions impl
+func (g *RestGateway) GetPoolTransactions(addr string) ([]*ElectTx, error) {
+	return GetPoolTransactions(g.bridge, g.getRestAddress(addr))
+}
+
+// GetTransactionHistory impl
+func (g *RestGateway) GetTransactionHistory(addr, lastSeenTxid string) ([]*ElectTx, error) {
+	return GetTransactionHistory(g.bridge, g.getRestAddress(addr), lastSeenTxid)
+}
+
+// GetOutspend impl
+func (g *RestGateway) GetOutspend(txHash string, vout uint32) (*ElectOutspend, error) {
+	return GetOutspend(g.bridge, txHash, vout)
+}
+
+// PostTransaction impl
+func (g *RestGateway) PostTransaction(txHex string) (txHash string, err error) {
+	return PostTransaction(g.bridge, txHex)
+}
+
+// GetBlockHash impl
+func (g *RestGateway) GetBlockHash(height uint64) (string, error) {
+	return GetBlockHash(g.bridge, height)
+}
+
+// GetBlockTxids impl
+func (g *RestGateway) GetBlockTxids(blockHash string) ([]string, error) {
+	return GetBlockTxids(g.bridge, blockHash)
+}
+
+// GetBlock impl
+func (g *RestGateway) GetBlock(blockHash string) (*ElectBlock, error) {
+	return GetBlock(g.bridge, blockHash)
+}
+
+// GetBlockTransactions impl
+func (g *RestGateway) GetBlockTransactions(blockHash string, startIndex uint32) ([]*ElectTx, error) {
+	return GetBlockTransactions(g.bridge, blockHash, startIndex)
+}
+
+// EstimateFeePerKb impl
+func (g *RestGateway) EstimateFeePerKb(blocks int) (int64, error) {
+	return EstimateFeePerKb(g.bridge, blocks)
+}
@@ -25,4 +25,6 @@ type BridgeInterface interface {
 	StartPoolTransactionScanJob()
 
 	ShouldAggregate(aggUtxoCount int, aggSumVal uint64) bool
+	GetAggregateRelayFee() (relayFeePerKb int64, isLowFee bool, err error)
+	IsAggregateDust(value uint64, relayFeePerKb int64) bool
 }
@@ -0,0 +1,151 @@
+package btc
+
+import (
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet/txsizes"
+)
+
+// upper bounds of utxo value histogram (unit satoshi)
+var utxoHistogramBounds = []uint64{1e4, 1e5, 1e6, 1e7, 1e8}
+
+var (
+	cachedUtxoInfo     *tokens.UtxoInfo
+	cachedUtxoInfoLock sync.RWMutex
+)
+
+// swapout tx spends one p2pkh input and has pay-to, memo and change outputs
+var estimatedSwapoutTxSize = txsizes.EstimateSerializeSize(1, []*wire.TxOut{
+	wire.NewTxOut(0, make([]byte, txsizes.P2PKHPkScriptSize)),
+	wire.NewTxOut(0, make([]byte, 2+len(tokens.UnlockMemoPrefix)+64)),
+}, true)
+
+// GetUtxoInfo get utxo statistics of address
+func GetUtxoInfo(b BridgeInterface, address string) (*tokens.UtxoInfo, error) {
+	relayFeePerKb, _, err := b.GetAggregateRelayFee()
+	if err != nil {
+		return nil, err
+	}
+	utxos, err := b.FindUtxos(address)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &tokens.UtxoInfo{
+		Address:       address,
+		RelayFeePerKb: relayFeePerKb,
+		DustThreshold: uint64(txsizes.RedeemP2PKHInputSize) * uint64(relayFeePerKb) / 1000,
+		SwapoutFee:    uint64(estimatedSwapoutTxSize) * uint64(relayFeePerKb) / 1000,
+		Histogram:     make([]*tokens.UtxoHistogramItem, len(utxoHistogramBounds)+1),
+	}
+	var minValue uint64
+	for i := range info.Histogram {
+		item := &tokens.UtxoHistogramItem{MinValue: minValue}
+		if i < len(utxoHistogramBounds) {
+			item.MaxValue = utxoHistogramBounds[i]
+			minValue = item.MaxValue
+		}
+		info.Histogram[i] = item
+	}
+
+	for _, utxo := range utxos {
+		if utxo.Value == nil || utxo.Txid == nil || utxo.Vout == nil {
+			continue
+		}
+		value := *utxo.Value
+		info.UtxoCount++
+		info.TotalValue += value
+		addToHistogram(info.Histogram, value)
+
+		if value <= info.DustThreshold {
+			info.DustCount++
+			info.DustValue += value
+			continue
+		}
+		if utxo.Status == nil || utxo.Status.Confirmed == nil || !*utxo.Status.Confirmed {
+			info.UnconfirmedCount++
+			info.UnconfirmedValue += value
+			continue
+		}
+		if isPendingSpend(b, utxo) {
+			info.PendingSpendCount++
+			info.PendingSpendValue += value
+			continue
+		}
+		info.SpendableValue += value
+		if value > info.SwapoutFee {
+			info.ProjectedSwapouts++
+		}
+	}
+	return info, nil
+}
+
+// ProjectPendingSwapouts project balance and utxo count after sending pending swapouts
+func ProjectPendingSwapouts(info *tokens.UtxoInfo, pendingValues []uint64) {
+	info.PendingSwapoutCount = len(pendingValues)
+	info.PendingSwapoutValue = 0
+	for _, value := range pendingValues {
+		info.PendingSwapoutValue += value
+	}
+	pendingFees := uint64(len(pendingValues)) * info.SwapoutFee
+	info.ProjectedBalance = int64(info.SpendableValue+info.UnconfirmedValue) - int64(info.PendingSwapoutValue+pendingFees)
+	info.ProjectedUtxoCount = info.ProjectedSwapouts - len(pendingValues)
+	if info.ProjectedUtxoCount < 0 {
+		info.ProjectedUtxoCount = 0
+	}
+}
+
+// SetCachedUtxoInfo set cached utxo info (updated by server job)
+func SetCachedUtxoInfo(info *tokens.UtxoInfo) {
+	cachedUtxoInfoLock.Lock()
+	defer cachedUtxoInfoLock.Unlock()
+	cachedUtxoInfo = info
+}
+
+// GetCachedUtxoInfo get cached utxo info
+func GetCachedUtxoInfo() *tokens.UtxoInfo {
+	cachedUtxoInfoLock.RLock()
+	defer cachedUtxoInfoLock.RUnlock()
+	return cachedUtxoInfo
+}
+
+// GetP2shBalance get balance of p2sh address
+func GetP2shBalance(b BridgeInterface, bindAddress, p2shAddress string) (*tokens.P2shBalance, error) {
+	utxos, err := b.FindUtxos(p2shAddress)
+	if err != nil {
+		return nil, err
+	}
+	balance := &tokens.P2shBalance{
+		BindAddress: bindAddress,
+		P2shAddress: p2shAddress,
+	}
+	for _, utxo := range utxos {
+		if utxo.Value == nil || *utxo.Value == 0 {
+			continue
+		}
+		balance.UtxoCount++
+		balance.Value += *utxo.Value
+	}
+	return balance, nil
+}
+
+func addToHistogram(histogram []*tokens.UtxoHistogramItem, value uint64) {
+	for _, item := range histogram {
+		if item.MaxValue == 0 || value < item.MaxValue {
+			item.Count++
+			item.Value += value
+			return
+		}
+	}
+}
+
+func isPendingSpend(b BridgeInterface, utxo *electrs.ElectUtxo) bool {
+	outspend, err := b.GetOutspend(*utxo.Txid, *utxo.Vout)
+	if err != nil || outspend.Spent == nil {
+		return false
+	}
+	return *outspend.Spent
+}
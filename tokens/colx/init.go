@@ -15,9 +15,11 @@ var (
 
 	cfgFromPublicKey string
 
-	cfgUtxoAggregateMinCount  = 1
-	cfgUtxoAggregateMinValue  = uint64(100000000)
-	cfgUtxoAggregateToAddress string
+	cfgUtxoAggregateMinCount    = 1
+	cfgUtxoAggregateMinValue    = uint64(100000000)
+	cfgUtxoAggregateToAddress   string
+	cfgUtxoAggregateMaxInputs   = 100
+	cfgUtxoAggregateMaxFeePerKb int64 // zero means no limit
 )
 
 // Init init colx extra
@@ -105,5 +107,15 @@ func initAggregate(btcExtra *tokens.BtcExtraConfig) {
 		log.Fatal("wrong utxo aggregate to address", "toAddress", cfgUtxoAggregateToAddress)
 	}
 
-	log.Info("Init Btc extra", "UtxoAggregateMinCount", cfgUtxoAggregateMinCount, "UtxoAggregateMinValue", cfgUtxoAggregateMinValue, "UtxoAggregateToAddress", cfgUtxoAggregateToAddress)
+	if btcExtra.UtxoAggregateMaxInputs > 0 {
+		cfgUtxoAggregateMaxInputs = btcExtra.UtxoAggregateMaxInputs
+	}
+
+	if cfgUtxoAggregateMinCount > cfgUtxoAggregateMaxInputs {
+		log.Fatal("UtxoAggregateMinCount is larger than UtxoAggregateMaxInputs", "min", cfgUtxoAggregateMinCount, "max", cfgUtxoAggregateMaxInputs)
+	}
+
+	cfgUtxoAggregateMaxFeePerKb = btcExtra.UtxoAggregateMaxFeePerKb
+
+	log.Info("Init Btc extra", "UtxoAggregateMinCount", cfgUtxoAggregateMinCount, "UtxoAggregateMinValue", cfgUtxoAggregateMinValue, "UtxoAggregateToAddress", cfgUtxoAggregateToAddress, "UtxoAggregateMaxInputs", cfgUtxoAggregateMaxInputs, "UtxoAggregateMaxFeePerKb", cfgUtxoAggregateMaxFeePerKb)
 }
@@ -1,10 +1,12 @@
 package colx
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
@@ -75,6 +77,10 @@ func (b *Bridge) StartChainTransactionScanJob() {
 				continue
 			}
 			txids, err := b.GetBlockTxids(blockHash)
+			if errors.Is(err, electrs.ErrNotSupportedByGateway) {
+				log.Warnf("[scanchain] stop %v scan chain job as %v", chainName, err)
+				return
+			}
 			if err != nil {
 				log.Error(errorSubject, "height", h, "blockHash", blockHash, "err", err)
 				time.Sleep(retryIntervalInScanJob)
@@ -137,6 +137,39 @@ func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tok
 	return swapInfo, nil
 }
 
+// VerifyTransactionOf verify swap tx through specified electrs api address,
+// require the tx is stable and the deposit is the same as the verified swap info.
+func (b *Bridge) VerifyTransactionOf(apiAddress string, swapInfo *tokens.TxSwapInfo) (*tokens.TxStatus, error) {
+	tokenCfg := b.GetTokenConfig(swapInfo.PairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	txStatus, err := electrs.GetTransactionStatusOf(apiAddress, swapInfo.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if txStatus.BlockHeight == 0 || txStatus.Confirmations < *b.GetChainConfig().Confirmations {
+		return txStatus, tokens.ErrTxNotStable
+	}
+	tx, err := electrs.GetTransactionByHashOf(apiAddress, swapInfo.Hash)
+	if err != nil {
+		return txStatus, err
+	}
+	depositAddress := tokenCfg.DepositAddress
+	value, memoScript, rightReceiver := b.GetReceivedValue(tx.Vout, depositAddress, p2pkhType)
+	if !rightReceiver {
+		return txStatus, tokens.ErrTxWithWrongReceiver
+	}
+	bindAddress, _ := GetBindAddressFromMemoScipt(memoScript)
+	if swapInfo.Value == nil || swapInfo.Value.Cmp(common.BigFromUint64(value)) != 0 ||
+		swapInfo.To != depositAddress ||
+		swapInfo.Bind != bindAddress ||
+		swapInfo.From != getTxFrom(tx.Vin, depositAddress) {
+		return txStatus, tokens.ErrTxInfoMismatch
+	}
+	return txStatus, nil
+}
+
 func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
 	if swapInfo.From == swapInfo.To {
 		return tokens.ErrTxWithWrongSender
@@ -20,9 +20,11 @@ type BtcExtraConfig struct {
 	PlusFeePercentage uint64
 	EstimateFeeBlocks int
 
-	UtxoAggregateMinCount  int
-	UtxoAggregateMinValue  uint64
-	UtxoAggregateToAddress string
+	UtxoAggregateMinCount    int
+	UtxoAggregateMinValue    uint64
+	UtxoAggregateToAddress   string
+	UtxoAggregateMaxInputs   int
+	UtxoAggregateMaxFeePerKb int64
 }
 
 // GatewayConfig struct
@@ -36,6 +38,15 @@ type GatewayConfig struct {
 // GatewayExtras struct
 type GatewayExtras struct {
 	BlockExtra *BlockExtraArgs
+	BtcExtra   *BtcGatewayExtraArgs `json:",omitempty"`
+}
+
+// BtcGatewayExtraArgs struct
+type BtcGatewayExtraArgs struct {
+	GatewayType        string                // electrs (default), electrum or bitcoind
+	InsecureSkipVerify bool                  `json:",omitempty"` // skip verify certificate of electrum ssl server
+	RequestTimeout     uint64                `json:",omitempty"` // seconds
+	CoreAPIs           []BlocknetCoreAPIArgs `json:",omitempty"` // bitcoind json rpc
 }
 
 // BlockExtraArgs struct
@@ -52,6 +63,14 @@ type BlocknetCoreAPIArgs struct {
 	DisableTLS  bool
 }
 
+// GetBtcGatewayExtra get btc gateway extra args
+func (c *GatewayConfig) GetBtcGatewayExtra() *BtcGatewayExtraArgs {
+	if c.Extras == nil {
+		return nil
+	}
+	return c.Extras.BtcExtra
+}
+
 // ChainConfig struct
 type ChainConfig struct {
 	BlockChain    string
@@ -72,6 +91,10 @@ type ChainConfig struct {
 	// judge by the 'to' chain (eg. dst for swapin)
 	EnableReplaceSwap  bool
 	EnableDynamicFeeTx bool
+	// simulate swap tx by `eth_createAccessList` and attach EIP-2930 access list
+	EnableAccessList bool `json:",omitempty"`
+	// safety margin percentage added to estimated gas (default 30)
+	EstimateGasMargin uint64 `json:",omitempty"`
 
 	AllowCallByContract             bool
 	CallByContractWhitelist         []string `json:",omitempty"`
@@ -140,14 +163,46 @@ type TokenConfig struct {
 	IsMappingTokenProxy    bool   `json:",omitempty"` // VTX
 
 	DefaultGasLimit          uint64 `json:",omitempty"`
+	MaxGasLimit              uint64 `json:",omitempty"` // cap of estimated gas limit
 	AllowSwapinFromContract  bool   `json:",omitempty"`
 	AllowSwapoutFromContract bool   `json:",omitempty"`
 
+	// swapin deposited by smart wallets (ERC-4337 accounts and Safe wallets),
+	// which is attributed to and bound to the smart account (source ERC20 only)
+	AllowSwapinFromSmartWallet bool     `json:",omitempty"`
+	SmartWalletEntryPoints     []string `json:",omitempty"` // trusted ERC-4337 entry points (default official ones)
+	SmartWalletMustExistOnDest bool     `json:",omitempty"` // smart account must be deployed on dest chain
+	SmartWalletSafeCodeHashes  []string `json:",omitempty"` // trusted Safe proxy code hashes (Safe is disabled if empty)
+
 	BigValueWhitelist []string `json:",omitempty"`
 
+	// allowed target contracts of swap-and-call (dest token only)
+	CallTargetWhitelist []string `json:",omitempty"`
+
+	// per user deposit addresses (CREATE2 forwarders deployed by factory)
+	DepositForwarderFactory      string `json:",omitempty"`
+	DepositForwarderInitCodeHash string `json:",omitempty"`
+
 	// use private key address instead
 	DcrmAddressPriKey string `json:"-"`
 
+	// sign with this signer instead of dcrm
+	Signer *SignerConfig `json:"-"`
+
+	// relayed swapouts (dest token only), relayer pays gas of `SwapoutWithPermit`
+	// and the gas cost is deducted from swap value with rate of
+	// token amount per native coin (both in whole unit)
+	RelayerAddress      string  `json:",omitempty"`
+	RelayGasTokenRate   float64 `json:",omitempty"`
+	RelayerKeystoreFile string  `json:"-"`
+	RelayerPasswordFile string  `json:"-"`
+
+	// abi driven swap events and functions instead of builtin ones
+	SwapABI *SwapABIConfig `json:"-"`
+
+	// native gas airdrop to fresh bind address after swapin (dest token only)
+	GasAirdrop *GasAirdropConfig `json:",omitempty"`
+
 	// calced value
 	maxSwap          *big.Int
 	minSwap          *big.Int
@@ -156,7 +211,169 @@ type TokenConfig struct {
 	bigValThreshhold *big.Int
 
 	bigValueWhitelist map[string]struct{}
+	callTargets       map[string]struct{}
+	entryPoints       map[string]struct{}
+	safeCodeHashes    map[string]struct{}
 	RippleExtra       *RippleTokenExtra
+
+	signer Signer
+}
+
+// SignerConfig struct
+type SignerConfig struct {
+	Type string // dcrm (default), keystore, pkcs11 or remote
+
+	// keystore signer
+	KeystoreFile string
+	PasswordFile string
+
+	// pkcs11 signer (HSM, eg. SoftHSM)
+	Pkcs11Module     string
+	Pkcs11TokenLabel string
+	Pkcs11KeyLabel   string
+	Pkcs11PinFile    string
+
+	// remote signer
+	APIAddress     string
+	RequestTimeout int // seconds
+}
+
+// CheckConfig check signer config
+func (c *SignerConfig) CheckConfig() error {
+	switch c.Type {
+	case "", DcrmSignerType:
+	case KeystoreSignerType:
+		if c.KeystoreFile == "" || c.PasswordFile == "" {
+			return errors.New("keystore signer must config 'KeystoreFile' and 'PasswordFile'")
+		}
+	case Pkcs11SignerType:
+		if c.Pkcs11Module == "" || c.Pkcs11TokenLabel == "" || c.Pkcs11KeyLabel == "" || c.Pkcs11PinFile == "" {
+			return errors.New("pkcs11 signer must config 'Pkcs11Module', 'Pkcs11TokenLabel', 'Pkcs11KeyLabel' and 'Pkcs11PinFile'")
+		}
+	case RemoteSignerType:
+		if c.APIAddress == "" {
+			return errors.New("remote signer must config 'APIAddress'")
+		}
+	default:
+		return fmt.Errorf("unknown signer type '%v'", c.Type)
+	}
+	return nil
+}
+
+// SwapABIConfig abi driven swap config of token contract.
+// events and functions are specified by name or signature,
+// and their arguments are specified by name.
+type SwapABIConfig struct {
+	ABIFile string
+
+	// erc20 like deposit event of source token, eg. `Transfer`
+	DepositEvent     string
+	DepositFromArg   string
+	DepositToArg     string
+	DepositAmountArg string
+
+	// swapout event and burn function of mapping token, eg. `LogSwapout`, `Swapout`
+	SwapoutEvent     string
+	SwapoutBindArg   string
+	SwapoutAmountArg string
+	BurnFunction     string
+
+	// mint function of mapping token, eg. `Swapin`
+	MintFunction  string
+	MintTxHashArg string
+	MintBindArg   string
+	MintAmountArg string
+}
+
+// CheckConfig check swap abi config
+func (c *SwapABIConfig) CheckConfig(isSrc bool) error {
+	if c.ABIFile == "" {
+		return errors.New("swap abi must config 'ABIFile'")
+	}
+	if isSrc {
+		if c.SwapoutEvent != "" || c.BurnFunction != "" || c.MintFunction != "" {
+			return errors.New("swap abi of source token forbid config swapout event, burn and mint function")
+		}
+		if c.DepositEvent == "" {
+			return errors.New("swap abi of source token must config 'DepositEvent'")
+		}
+		if c.DepositFromArg == "" || c.DepositToArg == "" || c.DepositAmountArg == "" {
+			return errors.New("swap abi must config 'DepositFromArg', 'DepositToArg' and 'DepositAmountArg'")
+		}
+		return nil
+	}
+	if c.DepositEvent != "" {
+		return errors.New("swap abi of dest token forbid config 'DepositEvent'")
+	}
+	if c.SwapoutEvent == "" || c.MintFunction == "" {
+		return errors.New("swap abi of dest token must config 'SwapoutEvent' and 'MintFunction'")
+	}
+	if c.SwapoutBindArg == "" || c.SwapoutAmountArg == "" {
+		return errors.New("swap abi must config 'SwapoutBindArg' and 'SwapoutAmountArg'")
+	}
+	if c.MintTxHashArg == "" || c.MintBindArg == "" || c.MintAmountArg == "" {
+		return errors.New("swap abi must config 'MintTxHashArg', 'MintBindArg' and 'MintAmountArg'")
+	}
+	return nil
+}
+
+// GasAirdropConfig native gas airdrop config.
+// airdrop is sent once per bind address from a separate funded account,
+// and only for swapin whose swap fee is enough to pay for it.
+type GasAirdropConfig struct {
+	SenderAddress string
+	KeystoreFile  string `json:"-"`
+	PasswordFile  string `json:"-"`
+
+	Amount           float64 // native coin (whole unit) of each airdrop
+	NativePrice      float64 // price of one native coin in token whole unit
+	BalanceThreshold float64 // airdrop if native balance is below this value (whole unit)
+	DailyBudget      float64 // maximum native coin (whole unit) airdropped per day
+	MinimumSwapFee   float64 // minimum swap fee (token whole unit) of swapin to airdrop
+}
+
+// CheckConfig check gas airdrop config
+func (c *GasAirdropConfig) CheckConfig(dcrmAddress string) error {
+	if !common.IsHexAddress(c.SenderAddress) || common.IsEqualIgnoreCase(c.SenderAddress, dcrmAddress) {
+		return errors.New("wrong gas airdrop 'SenderAddress'")
+	}
+	if c.Amount <= 0 {
+		return errors.New("gas airdrop must config positive 'Amount'")
+	}
+	if c.NativePrice <= 0 {
+		return errors.New("gas airdrop must config positive 'NativePrice'")
+	}
+	if c.DailyBudget < c.Amount {
+		return errors.New("gas airdrop 'DailyBudget' is less than 'Amount'")
+	}
+	if c.BalanceThreshold < 0 || c.MinimumSwapFee < 0 {
+		return errors.New("gas airdrop forbid negative 'BalanceThreshold' or 'MinimumSwapFee'")
+	}
+	return nil
+}
+
+// GetAirdropCost get the airdrop cost (token whole unit) which is paid from swap fee
+func (c *GasAirdropConfig) GetAirdropCost() float64 {
+	return c.Amount * c.NativePrice
+}
+
+// IsSwapFeeEnough is swap fee (in token decimals) enough to pay for the airdrop
+func (c *GasAirdropConfig) IsSwapFeeEnough(swapFee *big.Int, decimals uint8) bool {
+	minFee := c.GetAirdropCost()
+	if minFee < c.MinimumSwapFee {
+		minFee = c.MinimumSwapFee
+	}
+	return swapFee != nil && swapFee.Cmp(ToBits(minFee, decimals)) >= 0
+}
+
+// IsBudgetEnough is daily budget enough for another airdrop after airdropped count
+func (c *GasAirdropConfig) IsBudgetEnough(airdroppedCount int64) bool {
+	return float64(airdroppedCount+1)*c.Amount <= c.DailyBudget
+}
+
+// IsBelowBalanceThreshold is native balance (in wei) below airdrop threshold
+func (c *GasAirdropConfig) IsBelowBalanceThreshold(balance *big.Int) bool {
+	return balance != nil && balance.Cmp(ToBits(c.BalanceThreshold, 18)) < 0
 }
 
 // RippleTokenExtra ripple extra
@@ -187,6 +404,9 @@ func (c *ChainConfig) CheckConfig(isServer bool) error {
 	if c.BaseFeePercent < -90 || c.BaseFeePercent > 500 {
 		return errors.New("'BaseFeePercent' must be in range [-90, 500]")
 	}
+	if c.EstimateGasMargin > 100 {
+		return errors.New("'EstimateGasMargin' is too large (>100)")
+	}
 	if c.MaxGasPriceFluctPercent > 100 {
 		return errors.New("'MaxGasPriceFluctPercent' is too large (>100)")
 	}
@@ -341,6 +561,9 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 	if c.PlusGasPricePercentage > MaxPlusGasPricePercentage {
 		return errors.New("too large 'PlusGasPricePercentage' value")
 	}
+	if c.MaxGasLimit > 0 && c.MaxGasLimit < c.DefaultGasLimit {
+		return errors.New("wrong token config, MaxGasLimit < DefaultGasLimit")
+	}
 	if c.BigValueThreshold == nil {
 		return errors.New("token must config 'BigValueThreshold'")
 	}
@@ -361,6 +584,13 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 			return errors.New("only source ERC20 token allow swapin from contract")
 		}
 	}
+	if c.AllowSwapinFromSmartWallet {
+		if !isSrc || !c.IsErc20() {
+			return errors.New("only source ERC20 token allow swapin from smart wallet")
+		}
+	} else if len(c.SmartWalletEntryPoints) > 0 || len(c.SmartWalletSafeCodeHashes) > 0 || c.SmartWalletMustExistOnDest {
+		return errors.New("token config smart wallet options but not 'AllowSwapinFromSmartWallet'")
+	}
 	if c.IsProxyErc20() {
 		if !isSrc {
 			return errors.New("token ProxyERC20 is only support in source chain")
@@ -390,6 +620,60 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 	} else if c.DelegateToken != "" {
 		return errors.New("token forbid config 'DelegateToken' if 'IsDelegateContract' is false")
 	}
+	if c.DepositForwarderFactory != "" || c.DepositForwarderInitCodeHash != "" {
+		if !isSrc {
+			return errors.New("token deposit forwarder is only support in source chain")
+		}
+		if !common.IsHexAddress(c.DepositForwarderFactory) {
+			return errors.New("wrong 'DepositForwarderFactory' address")
+		}
+		if len(common.FromHex(c.DepositForwarderInitCodeHash)) != common.HashLength {
+			return errors.New("wrong 'DepositForwarderInitCodeHash' hash")
+		}
+	}
+	if c.Signer != nil {
+		err = c.Signer.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	if c.RelayerAddress != "" {
+		if isSrc {
+			return errors.New("token relayer is only support in dest chain")
+		}
+		if !common.IsHexAddress(c.RelayerAddress) || common.IsEqualIgnoreCase(c.RelayerAddress, c.DcrmAddress) {
+			return errors.New("wrong 'RelayerAddress' address")
+		}
+		if c.RelayGasTokenRate <= 0 {
+			return errors.New("token relayer must config positive 'RelayGasTokenRate'")
+		}
+	} else if c.RelayerKeystoreFile != "" {
+		return errors.New("token forbid config 'RelayerKeystoreFile' if 'RelayerAddress' is empty")
+	}
+	if c.GasAirdrop != nil {
+		if isSrc {
+			return errors.New("token gas airdrop is only support in dest chain")
+		}
+		err = c.GasAirdrop.CheckConfig(c.DcrmAddress)
+		if err != nil {
+			return err
+		}
+	}
+	if c.SwapABI != nil {
+		if c.ContractAddress == "" {
+			return errors.New("token must config 'ContractAddress' if 'SwapABI' is configed")
+		}
+		if isSrc && !c.IsErc20() {
+			return errors.New("swap abi of source token is only support for ERC20 token")
+		}
+		if c.IsDelegateContract || c.IsMappingTokenProxy || c.IsAnyswapAdapter {
+			return errors.New("swap abi can not be used with delegated, MappingTokenProxy or AnyswapAdapter token")
+		}
+		err = c.SwapABI.CheckConfig(isSrc)
+		if err != nil {
+			return err
+		}
+	}
 	err = c.VerifyDcrmPublicKey()
 	if err != nil {
 		return err
@@ -410,6 +694,51 @@ func (c *TokenConfig) CheckConfig(isSrc bool) (err error) {
 			c.bigValueWhitelist[key] = struct{}{}
 		}
 	}
+	if len(c.CallTargetWhitelist) > 0 {
+		if isSrc {
+			return errors.New("token 'CallTargetWhitelist' is only support in dest chain")
+		}
+		if c.IsDelegateContract || c.IsMappingTokenProxy || c.SwapABI != nil {
+			return errors.New("swap-and-call can not be used with delegated, MappingTokenProxy or SwapABI token")
+		}
+		c.callTargets = make(map[string]struct{}, len(c.CallTargetWhitelist))
+		for _, addr := range c.CallTargetWhitelist {
+			if !common.IsHexAddress(addr) {
+				return fmt.Errorf("wrong address '%v' in 'CallTargetWhitelist'", addr)
+			}
+			key := strings.ToLower(addr)
+			if _, exist := c.callTargets[key]; exist {
+				return fmt.Errorf("duplicate address '%v' in 'CallTargetWhitelist'", addr)
+			}
+			c.callTargets[key] = struct{}{}
+		}
+	}
+	if len(c.SmartWalletEntryPoints) > 0 {
+		c.entryPoints = make(map[string]struct{}, len(c.SmartWalletEntryPoints))
+		for _, addr := range c.SmartWalletEntryPoints {
+			if !common.IsHexAddress(addr) {
+				return fmt.Errorf("wrong address '%v' in 'SmartWalletEntryPoints'", addr)
+			}
+			key := strings.ToLower(addr)
+			if _, exist := c.entryPoints[key]; exist {
+				return fmt.Errorf("duplicate address '%v' in 'SmartWalletEntryPoints'", addr)
+			}
+			c.entryPoints[key] = struct{}{}
+		}
+	}
+	if len(c.SmartWalletSafeCodeHashes) > 0 {
+		c.safeCodeHashes = make(map[string]struct{}, len(c.SmartWalletSafeCodeHashes))
+		for _, codehash := range c.SmartWalletSafeCodeHashes {
+			if !common.IsHexHash(codehash) {
+				return fmt.Errorf("wrong codeHash '%v' in 'SmartWalletSafeCodeHashes'", codehash)
+			}
+			key := strings.ToLower(codehash)
+			if _, exist := c.safeCodeHashes[key]; exist {
+				return fmt.Errorf("duplicate codeHash '%v' in 'SmartWalletSafeCodeHashes'", codehash)
+			}
+			c.safeCodeHashes[key] = struct{}{}
+		}
+	}
 	log.Info("check token config success",
 		"id", c.ID, "name", c.Name, "symbol", c.Symbol, "decimals", *c.Decimals,
 		"depositAddress", c.DepositAddress, "contractAddress", c.ContractAddress,
@@ -544,6 +873,11 @@ func (c *TokenConfig) IsErc20() bool {
 	return strings.EqualFold(c.ID, "ERC20") || c.IsProxyErc20()
 }
 
+// HasDepositForwarder return if token support per user deposit addresses
+func (c *TokenConfig) HasDepositForwarder() bool {
+	return c.DepositForwarderFactory != ""
+}
+
 // IsProxyErc20 return if token is proxy contract of erc20
 func (c *TokenConfig) IsProxyErc20() bool {
 	return strings.EqualFold(c.ID, "ProxyERC20")
@@ -558,6 +892,33 @@ func (c *TokenConfig) IsInBigValueWhitelist(caller string) bool {
 	return exist
 }
 
+// IsInCallTargetWhitelist is allowed target contract of swap-and-call
+func (c *TokenConfig) IsInCallTargetWhitelist(target string) bool {
+	if c.callTargets == nil {
+		return false
+	}
+	_, exist := c.callTargets[strings.ToLower(target)]
+	return exist
+}
+
+// IsInSmartWalletEntryPoints is configed trusted ERC-4337 entry point
+func (c *TokenConfig) IsInSmartWalletEntryPoints(entryPoint string) bool {
+	if c.entryPoints == nil {
+		return false
+	}
+	_, exist := c.entryPoints[strings.ToLower(entryPoint)]
+	return exist
+}
+
+// IsInSmartWalletSafeCodeHashes is configed trusted Safe proxy code hash
+func (c *TokenConfig) IsInSmartWalletSafeCodeHashes(codehash string) bool {
+	if c.safeCodeHashes == nil {
+		return false
+	}
+	_, exist := c.safeCodeHashes[strings.ToLower(codehash)]
+	return exist
+}
+
 // GetDcrmAddressPrivateKey get private key
 func (c *TokenConfig) GetDcrmAddressPrivateKey() *string {
 	// get rid of '0x' prefix
@@ -575,7 +936,7 @@ func (c *TokenConfig) VerifyDcrmPublicKey() error {
 	if c.DcrmAddressPriKey != "" {
 		return nil
 	}
-	if IsDcrmDisabled {
+	if IsDcrmDisabled && c.GetSignerType() == DcrmSignerType {
 		return nil
 	}
 
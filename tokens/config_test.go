@@ -0,0 +1,73 @@
+package tokens
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGasAirdropConfig(t *testing.T) {
+	cfg := &GasAirdropConfig{
+		SenderAddress:    "0x6666666666666666666666666666666666666666",
+		Amount:           0.01,
+		NativePrice:      300,
+		BalanceThreshold: 0.001,
+		DailyBudget:      0.05,
+		MinimumSwapFee:   1,
+	}
+	if err := cfg.CheckConfig("0x1111111111111111111111111111111111111111"); err != nil {
+		t.Fatalf("check config failed: %v", err)
+	}
+	if err := cfg.CheckConfig(cfg.SenderAddress); err == nil {
+		t.Errorf("sender same as dcrm address should fail")
+	}
+	noPriceCfg := *cfg
+	noPriceCfg.NativePrice = 0
+	if err := noPriceCfg.CheckConfig("0x1111111111111111111111111111111111111111"); err == nil {
+		t.Errorf("config without native price should fail")
+	}
+
+	// airdrop cost is 0.01 * 300 = 3 tokens, which is higher than minimum swap fee
+	feeTests := []struct {
+		swapFee *big.Int
+		want    bool
+	}{
+		{nil, false},
+		{ToBits(1, 6), false},
+		{ToBits(2.999999, 6), false},
+		{ToBits(3, 6), true},
+		{ToBits(10, 6), true},
+	}
+	for i, test := range feeTests {
+		if got := cfg.IsSwapFeeEnough(test.swapFee, 6); got != test.want {
+			t.Errorf("fee test %v: swap fee %v, want %v, got %v", i, test.swapFee, test.want, got)
+		}
+	}
+	minFeeCfg := *cfg
+	minFeeCfg.MinimumSwapFee = 5
+	if minFeeCfg.IsSwapFeeEnough(ToBits(4, 6), 6) || !minFeeCfg.IsSwapFeeEnough(ToBits(5, 6), 6) {
+		t.Errorf("minimum swap fee higher than airdrop cost is not respected")
+	}
+
+	// daily budget 0.05 allows 5 airdrops of 0.01
+	for count, want := range []bool{true, true, true, true, true, false, false} {
+		if got := cfg.IsBudgetEnough(int64(count)); got != want {
+			t.Errorf("budget test: airdropped count %v, want %v, got %v", count, want, got)
+		}
+	}
+
+	thresholdTests := []struct {
+		balance *big.Int
+		want    bool
+	}{
+		{nil, false},
+		{big.NewInt(0), true},
+		{ToBits(0.0009, 18), true},
+		{ToBits(0.001, 18), false},
+		{ToBits(1, 18), false},
+	}
+	for i, test := range thresholdTests {
+		if got := cfg.IsBelowBalanceThreshold(test.balance); got != test.want {
+			t.Errorf("threshold test %v: balance %v, want %v, got %v", i, test.balance, test.want, got)
+		}
+	}
+}
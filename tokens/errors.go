@@ -38,6 +38,7 @@ var (
 	ErrSwapoutLogNotFound   = errors.New("swapout log not found or removed")
 	ErrUnknownPairID        = errors.New("unknown pair ID")
 	ErrBindAddressMismatch  = errors.New("bind address mismatch")
+	ErrTxInfoMismatch       = errors.New("tx info mismatch")
 	ErrRPCQueryError        = errors.New("rpc query error")
 	ErrWrongSwapValue       = errors.New("wrong swap value")
 	ErrTxIncompatible       = errors.New("tx incompatible")
@@ -48,6 +49,8 @@ var (
 	ErrTxWithWrongStatus    = errors.New("tx with wrong status")
 	ErrTxWithNoPayment      = errors.New("tx with no payment")
 	ErrTxIsNotValidated     = errors.New("tx is not validated")
+	ErrWrongSwapID          = errors.New("wrong swap ID")
+	ErrSwapTxWillRevert     = errors.New("swap tx will revert")
 
 	// errors should register
 	ErrTxWithWrongMemo       = errors.New("tx with wrong memo")
@@ -0,0 +1,353 @@
+package abicoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+)
+
+// abi entry types
+const (
+	ABIEventType    = "event"
+	ABIFunctionType = "function"
+)
+
+// abi errors
+var (
+	ErrABIEntryNotFound  = errors.New("abi entry not found")
+	ErrABIEntryAmbiguous = errors.New("abi entry is ambiguous, please specify signature")
+	ErrLogTopicMismatch  = errors.New("log topic mismatch")
+)
+
+// ABIArgument abi argument
+type ABIArgument struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+// ABIEntry abi entry of event or function
+type ABIEntry struct {
+	Type      string         `json:"type"`
+	Name      string         `json:"name"`
+	Inputs    []*ABIArgument `json:"inputs"`
+	Anonymous bool           `json:"anonymous"`
+}
+
+// ABI abi entries of contract
+type ABI struct {
+	Entries []*ABIEntry
+}
+
+// LoadABIFile load abi from json file
+func LoadABIFile(path string) (*ABI, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseABI(data)
+}
+
+// ParseABI parse abi json, which is abi array or
+// compiled artifact object with 'abi' field (eg. truffle, hardhat)
+func ParseABI(data []byte) (*ABI, error) {
+	var entries []*ABIEntry
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		var artifact struct {
+			ABI []*ABIEntry `json:"abi"`
+		}
+		if json.Unmarshal(data, &artifact) != nil || artifact.ABI == nil {
+			return nil, fmt.Errorf("parse abi failed: %w", err)
+		}
+		entries = artifact.ABI
+	}
+	return &ABI{Entries: entries}, nil
+}
+
+// GetEvent get event by name or signature (eg. `Swapout(uint256,address)`)
+func (a *ABI) GetEvent(nameOrSig string) (*ABIEntry, error) {
+	return a.getEntry(ABIEventType, nameOrSig)
+}
+
+// GetFunction get function by name or signature (eg. `Swapin(bytes32,address,uint256)`)
+func (a *ABI) GetFunction(nameOrSig string) (*ABIEntry, error) {
+	return a.getEntry(ABIFunctionType, nameOrSig)
+}
+
+func (a *ABI) getEntry(entryType, nameOrSig string) (entry *ABIEntry, err error) {
+	isSig := strings.Contains(nameOrSig, "(")
+	for _, e := range a.Entries {
+		if e.Type != entryType {
+			continue
+		}
+		if isSig {
+			if e.Signature() == nameOrSig {
+				entry = e
+				break
+			}
+		} else if e.Name == nameOrSig {
+			if entry != nil {
+				return nil, fmt.Errorf("%w: %v %v", ErrABIEntryAmbiguous, entryType, nameOrSig)
+			}
+			entry = e
+		}
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %v %v", ErrABIEntryNotFound, entryType, nameOrSig)
+	}
+	if err = entry.checkInputTypes(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (e *ABIEntry) checkInputTypes() error {
+	for _, input := range e.Inputs {
+		if !isSupportedType(input.Type) {
+			return fmt.Errorf("%v %v has unsupported argument type '%v'", e.Type, e.Name, input.Type)
+		}
+	}
+	return nil
+}
+
+// Signature get signature, eg. `Transfer(address,address,uint256)`
+func (e *ABIEntry) Signature() string {
+	types := make([]string, len(e.Inputs))
+	for i, input := range e.Inputs {
+		types[i] = input.Type
+	}
+	return fmt.Sprintf("%v(%v)", e.Name, strings.Join(types, ","))
+}
+
+// FuncHash get first 4 bytes of keccak256 hash of signature
+func (e *ABIEntry) FuncHash() []byte {
+	return e.Topic().Bytes()[:4]
+}
+
+// Topic get keccak256 hash of signature
+func (e *ABIEntry) Topic() common.Hash {
+	return common.Keccak256Hash([]byte(e.Signature()))
+}
+
+// HasInput has input argument of name
+func (e *ABIEntry) HasInput(name string) bool {
+	for _, input := range e.Inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UnpackLog unpack event log to named values.
+// indexed argument of dynamic type is unpacked as its keccak256 hash.
+func (e *ABIEntry) UnpackLog(topics []common.Hash, data []byte) (map[string]interface{}, error) {
+	topicIndex := 0
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Topic() {
+			return nil, ErrLogTopicMismatch
+		}
+		topicIndex = 1
+	}
+	values := make(map[string]interface{}, len(e.Inputs))
+	var dataPos uint64
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			if topicIndex >= len(topics) {
+				return nil, ErrLogTopicMismatch
+			}
+			topic := topics[topicIndex]
+			topicIndex++
+			if isDynamicType(input.Type) {
+				values[input.Name] = topic
+				continue
+			}
+			value, err := unpackWord(input.Type, topic.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			values[input.Name] = value
+			continue
+		}
+		value, err := unpackArgument(input.Type, data, dataPos)
+		if err != nil {
+			return nil, err
+		}
+		values[input.Name] = value
+		dataPos += 32
+	}
+	if topicIndex != len(topics) {
+		return nil, ErrLogTopicMismatch
+	}
+	return values, nil
+}
+
+// UnpackInput unpack function input (with func hash) to named values
+func (e *ABIEntry) UnpackInput(input []byte) (map[string]interface{}, error) {
+	if len(input) < 4 || !bytes.Equal(input[:4], e.FuncHash()) {
+		return nil, ErrParseDataError
+	}
+	data := input[4:]
+	values := make(map[string]interface{}, len(e.Inputs))
+	for i, arg := range e.Inputs {
+		value, err := unpackArgument(arg.Type, data, uint64(i*32))
+		if err != nil {
+			return nil, err
+		}
+		values[arg.Name] = value
+	}
+	return values, nil
+}
+
+// PackInput pack function input (with func hash) from named values
+func (e *ABIEntry) PackInput(values map[string]interface{}) ([]byte, error) {
+	args := make([]interface{}, len(e.Inputs))
+	for i, input := range e.Inputs {
+		value, exist := values[input.Name]
+		if !exist {
+			return nil, fmt.Errorf("missing argument '%v' of %v", input.Name, e.Signature())
+		}
+		arg, err := convertArgument(input.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("wrong argument '%v' of %v: %w", input.Name, e.Signature(), err)
+		}
+		args[i] = arg
+	}
+	return PackDataWithFuncHash(e.FuncHash(), args...), nil
+}
+
+func isDynamicType(typ string) bool {
+	return typ == "string" || typ == "bytes"
+}
+
+func isSupportedType(typ string) bool {
+	switch typ {
+	case "address", "bool", "string", "bytes":
+		return true
+	}
+	_, err := getTypeSize(typ)
+	return err == nil
+}
+
+// getTypeSize get size of `uintN`, `intN` in bits, or size of `bytesN` in bytes
+func getTypeSize(typ string) (int, error) {
+	var sizeStr string
+	var maxSize int
+	switch {
+	case strings.HasPrefix(typ, "uint"):
+		sizeStr, maxSize = typ[4:], 256
+	case strings.HasPrefix(typ, "int"):
+		sizeStr, maxSize = typ[3:], 256
+	case strings.HasPrefix(typ, "bytes"):
+		sizeStr, maxSize = typ[5:], 32
+	default:
+		return 0, fmt.Errorf("unsupported type '%v'", typ)
+	}
+	if sizeStr == "" && maxSize == 256 {
+		return 256, nil
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size <= 0 || size > maxSize || (maxSize == 256 && size%8 != 0) {
+		return 0, fmt.Errorf("unsupported type '%v'", typ)
+	}
+	return size, nil
+}
+
+func unpackArgument(typ string, data []byte, pos uint64) (interface{}, error) {
+	switch typ {
+	case "string":
+		return ParseStringInData(data, pos)
+	case "bytes":
+		return ParseBytesInData(data, pos)
+	}
+	if uint64(len(data)) < pos+32 {
+		return nil, ErrParseDataError
+	}
+	return unpackWord(typ, data[pos:pos+32])
+}
+
+func unpackWord(typ string, word []byte) (interface{}, error) {
+	switch {
+	case typ == "address":
+		return common.BytesToAddress(word), nil
+	case typ == "bool":
+		return word[31] != 0, nil
+	case strings.HasPrefix(typ, "uint"):
+		return new(big.Int).SetBytes(word), nil
+	case strings.HasPrefix(typ, "int"):
+		value := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return value, nil
+	case typ == "bytes32":
+		return common.BytesToHash(word), nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := getTypeSize(typ)
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Bytes(common.CopyBytes(word[:size])), nil
+	}
+	return nil, fmt.Errorf("unsupported type '%v'", typ)
+}
+
+// nolint:gocyclo // allow big switch
+func convertArgument(typ string, value interface{}) (interface{}, error) {
+	switch typ {
+	case "address":
+		switch v := value.(type) {
+		case common.Address:
+			return v, nil
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address '%v'", v)
+			}
+			return common.HexToAddress(v), nil
+		}
+	case "bool":
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case "string":
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case "bytes":
+		switch v := value.(type) {
+		case []byte, hexutil.Bytes:
+			return v, nil
+		case string:
+			return common.FromHex(v), nil
+		}
+	case "bytes32":
+		switch v := value.(type) {
+		case common.Hash:
+			return v, nil
+		case string:
+			return common.HexToHash(v), nil
+		}
+	default:
+		if strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "int") {
+			switch v := value.(type) {
+			case *big.Int:
+				if v.Sign() < 0 {
+					return nil, fmt.Errorf("negative number %v", v)
+				}
+				return v, nil
+			case uint64:
+				return v, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("can not convert %v (%T) to type '%v'", value, value, typ)
+}
@@ -0,0 +1,72 @@
+package abicoder
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+const testMappingTokenABI = `[
+	{"type":"function","name":"Swapin","inputs":[{"name":"txhash","type":"bytes32"},{"name":"account","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"Swapout","inputs":[{"name":"amount","type":"uint256"},{"name":"bindaddr","type":"address"}]},
+	{"type":"event","name":"LogSwapout","anonymous":false,"inputs":[{"name":"account","type":"address","indexed":true},{"name":"bindaddr","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]}
+]`
+
+func TestABI(t *testing.T) {
+	contractABI, err := ParseABI([]byte(testMappingTokenABI))
+	if err != nil {
+		t.Fatalf("parse abi failed: %v", err)
+	}
+
+	swapin, err := contractABI.GetFunction("Swapin")
+	if err != nil {
+		t.Fatalf("get function failed: %v", err)
+	}
+	if !bytes.Equal(swapin.FuncHash(), common.FromHex("0xec126c77")) {
+		t.Errorf("wrong func hash of %v: %x", swapin.Signature(), swapin.FuncHash())
+	}
+
+	txHash := common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	account := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	amount := big.NewInt(123456789)
+	input, err := swapin.PackInput(map[string]interface{}{"txhash": txHash, "account": account, "amount": amount})
+	if err != nil {
+		t.Fatalf("pack input failed: %v", err)
+	}
+	if !bytes.Equal(input, PackDataWithFuncHash(swapin.FuncHash(), txHash, account, amount)) {
+		t.Errorf("wrong packed input %x", input)
+	}
+	values, err := swapin.UnpackInput(input)
+	if err != nil {
+		t.Fatalf("unpack input failed: %v", err)
+	}
+	if values["txhash"] != txHash || values["account"] != account || values["amount"].(*big.Int).Cmp(amount) != 0 {
+		t.Errorf("wrong unpacked input %v", values)
+	}
+
+	logSwapout, err := contractABI.GetEvent("LogSwapout(address,address,uint256)")
+	if err != nil {
+		t.Fatalf("get event failed: %v", err)
+	}
+	wantTopic := common.HexToHash("0x6b616089d04950dc06c45c6dd787d657980543f89651aec47924752c7d16c888")
+	if logSwapout.Topic() != wantTopic {
+		t.Errorf("wrong topic of %v: %v", logSwapout.Signature(), logSwapout.Topic().String())
+	}
+	topics := []common.Hash{wantTopic, account.Hash(), account.Hash()}
+	values, err = logSwapout.UnpackLog(topics, PackData(amount))
+	if err != nil {
+		t.Fatalf("unpack log failed: %v", err)
+	}
+	if values["bindaddr"] != account || values["amount"].(*big.Int).Cmp(amount) != 0 {
+		t.Errorf("wrong unpacked log %v", values)
+	}
+	if _, err = logSwapout.UnpackLog(topics[:2], PackData(amount)); err == nil {
+		t.Errorf("unpack log with wrong topics should fail")
+	}
+
+	if _, err = contractABI.GetEvent("Transfer"); err == nil {
+		t.Errorf("get not exist event should fail")
+	}
+}
@@ -54,6 +54,10 @@ func PackData(args ...interface{}) []byte {
 			copy(bs[i*32:], packBigInt(big.NewInt(int64(v))))
 		case uint8:
 			copy(bs[i*32:], packBigInt(big.NewInt(int64(v))))
+		case bool:
+			if v {
+				bs[(i+1)*32-1] = 1
+			}
 		case []common.Address:
 			offset := big.NewInt(int64(len(bs)))
 			copy(bs[i*32:], packBigInt(offset))
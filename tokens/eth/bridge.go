@@ -110,6 +110,8 @@ func (b *Bridge) MakeSigner(chainID *big.Int) {
 	b.ChainConfig.SetChainID(chainID)
 	if b.ChainConfig.EnableDynamicFeeTx {
 		b.Signer = types.MakeSigner("London", chainID)
+	} else if b.ChainConfig.EnableAccessList {
+		b.Signer = types.MakeSigner("Berlin", chainID)
 	} else {
 		b.Signer = types.MakeSigner("EIP155", chainID)
 	}
@@ -117,24 +119,55 @@ func (b *Bridge) MakeSigner(chainID *big.Int) {
 
 // VerifyTokenConfig verify token config
 func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) (err error) {
-	if !b.IsValidAddress(tokenCfg.DcrmAddress) {
-		return fmt.Errorf("invalid dcrm address: %v", tokenCfg.DcrmAddress)
-	}
-	if b.IsSrc && !b.IsValidAddress(tokenCfg.DepositAddress) {
-		return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
+	for _, check := range b.tokenConfigChecks() {
+		if err = check.verify(tokenCfg); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	err = b.verifyDecimals(tokenCfg)
-	if err != nil {
-		return err
+// DryRunVerifyTokenConfig run every check of `VerifyTokenConfig`
+// and report result of each check (nil error means pass)
+func (b *Bridge) DryRunVerifyTokenConfig(tokenCfg *tokens.TokenConfig) (checkNames []string, checkErrs []error) {
+	for _, check := range b.tokenConfigChecks() {
+		checkNames = append(checkNames, check.name)
+		checkErrs = append(checkErrs, check.verify(tokenCfg))
 	}
+	return checkNames, checkErrs
+}
 
-	err = b.verifyContractAddress(tokenCfg)
-	if err != nil {
-		return err
-	}
+type tokenConfigCheck struct {
+	name   string
+	verify func(tokenCfg *tokens.TokenConfig) error
+}
 
-	return nil
+func (b *Bridge) tokenConfigChecks() []*tokenConfigCheck {
+	return []*tokenConfigCheck{
+		{"dcrm address", func(tokenCfg *tokens.TokenConfig) error {
+			if !b.IsValidAddress(tokenCfg.DcrmAddress) {
+				return fmt.Errorf("invalid dcrm address: %v", tokenCfg.DcrmAddress)
+			}
+			return nil
+		}},
+		{"deposit address", func(tokenCfg *tokens.TokenConfig) error {
+			if b.IsSrc && !b.IsValidAddress(tokenCfg.DepositAddress) {
+				return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
+			}
+			return nil
+		}},
+		{"swap abi", func(tokenCfg *tokens.TokenConfig) error {
+			if tokenCfg.SwapABI != nil {
+				swapABIs.Delete(tokenCfg)
+				if _, err := getSwapABI(tokenCfg); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"decimals", b.verifyDecimals},
+		{"contract address", b.verifyContractAddress},
+	}
 }
 
 func (b *Bridge) verifyDecimals(tokenCfg *tokens.TokenConfig) error {
@@ -194,12 +227,33 @@ func (b *Bridge) verifyContractAddress(tokenCfg *tokens.TokenConfig) error {
 			return fmt.Errorf("mismatch 'DelegateToken', has %v, want %v", tokenCfg.DelegateToken, proxyToken.String())
 		}
 	}
-	if !b.IsSrc && !tokenCfg.IsMappingTokenProxy {
+	if tokenCfg.SwapABI != nil {
+		sa, err := getSwapABI(tokenCfg)
+		if err != nil {
+			return err
+		}
+		err = b.VerifyContractCode(contractAddr, sa.codeParts())
+		if err != nil {
+			return fmt.Errorf("wrong swap abi contract address: %v, %w", contractAddr, err)
+		}
+	} else if !b.IsSrc && !tokenCfg.IsMappingTokenProxy {
 		err := b.VerifyAnyswapContractAddress(contractAddr)
 		if err != nil {
 			return fmt.Errorf("wrong anyswap contract address: %v, %w", contractAddr, err)
 		}
 	}
+	if len(tokenCfg.CallTargetWhitelist) > 0 {
+		err := b.VerifyContractCode(contractAddr, map[string][]byte{"SwapinAndCall": swapinAndCallFuncHash})
+		if err != nil {
+			return fmt.Errorf("wrong swap-and-call contract address: %v, %w", contractAddr, err)
+		}
+	}
+	if tokenCfg.RelayerAddress != "" {
+		err := b.VerifyContractCode(contractAddr, map[string][]byte{"SwapoutWithPermit": getSwapoutWithPermitFuncHash()})
+		if err != nil {
+			return fmt.Errorf("wrong relayed swapout contract address: %v, %w", contractAddr, err)
+		}
+	}
 	log.Info("verify contract address pass", "address", contractAddr)
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
 )
 
-// build input for calling `Swapin(bytes32 txhash, address account, uint256 amount)`
+// build input for calling `Swapin(bytes32 txhash, address account, uint256 amount)`,
+// or the mint function configed in swap abi of token,
+// or `SwapinAndCall(bytes32 txhash, address account, uint256 amount, address target, bytes data)`
 func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs) (err error) {
 	token := b.GetTokenConfig(args.PairID)
 	if token == nil {
@@ -29,9 +31,29 @@ func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs) (err error) {
 	}
 	args.SwapValue = swapValue // swap value
 
-	funcHash := getSwapinFuncHash()
-	txHash := common.HexToHash(args.SwapID)
-	input := abicoder.PackDataWithFuncHash(funcHash, txHash, receiver, swapValue)
+	// batched deposits in the same tx are minted with the same tx hash
+	swapTxHash, _, err := tokens.ParseSwapID(args.SwapID)
+	if err != nil {
+		return err
+	}
+	txHash := common.HexToHash(swapTxHash)
+	var input []byte
+	sa, err := getSwapABI(token)
+	if err != nil {
+		return err
+	}
+	if sa != nil {
+		input, err = sa.buildMintInput(txHash, receiver, swapValue)
+		if err != nil {
+			return err
+		}
+	} else if call := args.OriginCall; call != nil && token.IsInCallTargetWhitelist(call.Target) {
+		target := common.HexToAddress(call.Target)
+		input = abicoder.PackDataWithFuncHash(swapinAndCallFuncHash, txHash, receiver, swapValue, target, call.Data)
+	} else {
+		funcHash := getSwapinFuncHash()
+		input = abicoder.PackDataWithFuncHash(funcHash, txHash, receiver, swapValue)
+	}
 	args.Input = &input             // input
 	args.To = token.ContractAddress // to
 
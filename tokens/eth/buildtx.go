@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -17,7 +18,11 @@ var (
 	retryRPCCount    = 3
 	retryRPCInterval = 1 * time.Second
 
-	cachedNonce = make(map[string]uint64)
+	cachedNonce     = make(map[string]uint64)
+	cachedNonceLock sync.Mutex
+
+	// protect latestGasPrice as pre-signing adjusts gas price concurrently
+	latestGasPriceLock sync.Mutex
 
 	minReserveFee  *big.Int
 	latestGasPrice *big.Int
@@ -157,17 +162,17 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 	}
 	nonce := *extra.Nonce
 
-	key := strings.ToLower(fmt.Sprintf("%v:%v", b.SignerChainID, args.From))
-	cached := cachedNonce[key]
-	if (cached > 0 && (nonce > cached+1000 || nonce+1000 < cached)) ||
-		(cached == 0 && nonce > 10000000) {
-		return nil, fmt.Errorf("nonce is out of range. cached %v, your %v", cached, nonce)
+	err = b.checkAndCacheNonce(args.From, nonce)
+	if err != nil {
+		return nil, err
 	}
-	cachedNonce[key] = nonce
 
-	if isDynamicFeeTx {
-		rawTx = types.NewDynamicFeeTx(b.SignerChainID, nonce, &to, value, gasLimit, gasTipCap, gasFeeCap, input, nil)
-	} else {
+	switch {
+	case isDynamicFeeTx:
+		rawTx = types.NewDynamicFeeTx(b.SignerChainID, nonce, &to, value, gasLimit, gasTipCap, gasFeeCap, input, extra.AccessList)
+	case len(extra.AccessList) > 0:
+		rawTx = types.NewAccessListTx(b.SignerChainID, nonce, &to, value, gasLimit, gasPrice, input, extra.AccessList)
+	default:
 		rawTx = types.NewTransaction(nonce, to, value, gasLimit, gasPrice, input)
 	}
 
@@ -175,7 +180,7 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 		"chainID", b.SignerChainID, "pairID", args.PairID, "swapID", args.SwapID,
 		"from", args.From, "to", to.String(), "nonce", nonce, "bind", args.Bind,
 		"originValue", args.OriginValue, "swapValue", args.SwapValue,
-		"gasLimit", gasLimit, "data", common.ToHex(input),
+		"gasLimit", gasLimit, "accessList", len(extra.AccessList), "data", common.ToHex(input),
 		"replaceNum", args.GetReplaceNum(),
 	}
 	if gasTipCap != nil || gasFeeCap != nil {
@@ -188,6 +193,21 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 	return rawTx, nil
 }
 
+// checkAndCacheNonce check nonce is in range of the cached nonce of sender, and cache it.
+// tx builders run concurrently (swap, sweep, relay, gas airdrop), so access is locked.
+func (b *Bridge) checkAndCacheNonce(from string, nonce uint64) error {
+	key := strings.ToLower(fmt.Sprintf("%v:%v", b.SignerChainID, from))
+	cachedNonceLock.Lock()
+	defer cachedNonceLock.Unlock()
+	cached := cachedNonce[key]
+	if (cached > 0 && (nonce > cached+1000 || nonce+1000 < cached)) ||
+		(cached == 0 && nonce > 10000000) {
+		return fmt.Errorf("nonce is out of range. cached %v, your %v", cached, nonce)
+	}
+	cachedNonce[key] = nonce
+	return nil
+}
+
 func (b *Bridge) getMinReserveFee() *big.Int {
 	if minReserveFee != nil {
 		return minReserveFee
@@ -242,26 +262,10 @@ func (b *Bridge) setDefaults(args *tokens.BuildTxArgs) (err error) {
 	extra := getOrInitExtra(args)
 
 	if extra.Gas == nil {
-		var input []byte
-		if args.Input != nil {
-			input = *args.Input
-		}
-
-		esGasLimit, errf := b.EstimateGas(args.From, args.To, args.Value, input)
-		if errf != nil {
-			log.Error(fmt.Sprintf("build %s tx estimate gas failed", args.SwapType.String()),
-				"swapID", args.SwapID, "from", args.From, "to", args.To,
-				"value", args.Value, "data", common.ToHex(input), "err", errf)
-			return tokens.ErrEstimateGasFailed
-		}
-
-		esGasLimit += esGasLimit * 30 / 100
-		defGasLimit := b.getDefaultGasLimit(args.PairID)
-		if esGasLimit < defGasLimit {
-			esGasLimit = defGasLimit
+		err = b.setDefaultGasLimit(args, extra)
+		if err != nil {
+			return err
 		}
-		extra.Gas = new(uint64)
-		*extra.Gas = esGasLimit
 	}
 	return nil
 }
@@ -348,6 +352,8 @@ func (b *Bridge) adjustSwapGasPrice(args *tokens.BuildTxArgs, oldGasPrice *big.I
 	}
 	maxGasPriceFluctPercent := b.ChainConfig.MaxGasPriceFluctPercent
 	if maxGasPriceFluctPercent > 0 {
+		latestGasPriceLock.Lock()
+		defer latestGasPriceLock.Unlock()
 		if latestGasPrice != nil && newGasPrice.Cmp(latestGasPrice) < 0 {
 			maxFluct := new(big.Int).Set(latestGasPrice)
 			maxFluct.Mul(maxFluct, new(big.Int).SetUint64(maxGasPriceFluctPercent))
@@ -0,0 +1,43 @@
+package eth
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestCheckAndCacheNonce(t *testing.T) {
+	b := NewCrossChainBridge(false)
+	b.SignerChainID = big.NewInt(1)
+	from := "0x7777777777777777777777777777777777777777"
+
+	if err := b.checkAndCacheNonce(from, 10000001); err == nil {
+		t.Errorf("too large first nonce should fail")
+	}
+	if err := b.checkAndCacheNonce(from, 5000); err != nil {
+		t.Fatalf("check nonce failed: %v", err)
+	}
+	if err := b.checkAndCacheNonce(from, 6001); err == nil {
+		t.Errorf("nonce far above cached nonce should fail")
+	}
+	if err := b.checkAndCacheNonce(from, 3999); err == nil {
+		t.Errorf("nonce far below cached nonce should fail")
+	}
+
+	// tx builders run concurrently with different senders
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sender := big.NewInt(int64(i)).String()
+			for nonce := uint64(0); nonce < 100; nonce++ {
+				if err := b.checkAndCacheNonce(sender, nonce); err != nil {
+					t.Errorf("check nonce of sender %v failed: %v", sender, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -450,6 +450,45 @@ func getCode(contract string, urls []string) ([]byte, error) {
 	return nil, wrapRPCQueryError(err, "eth_getCode", contract)
 }
 
+// GetStorageAt call eth_getStorageAt
+func (b *Bridge) GetStorageAt(contract string, slot common.Hash, blockNumber string) (common.Hash, error) {
+	gateway := b.GatewayConfig
+	var result common.Hash
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_getStorageAt", contract, slot, blockNumber)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return common.Hash{}, wrapRPCQueryError(err, "eth_getStorageAt", contract)
+}
+
+// GetContractLogs call eth_getLogs
+func (b *Bridge) GetContractLogs(contractAddresses []common.Address, logTopics [][]common.Hash, fromHeight, toHeight uint64) ([]*types.RPCLog, error) {
+	filter := &types.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromHeight),
+		ToBlock:   new(big.Int).SetUint64(toHeight),
+		Addresses: contractAddresses,
+		Topics:    logTopics,
+	}
+	args, err := types.ToFilterArg(filter)
+	if err != nil {
+		return nil, err
+	}
+	gateway := b.GatewayConfig
+	var result []*types.RPCLog
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_getLogs", args)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return nil, wrapRPCQueryError(err, "eth_getLogs")
+}
+
 // CallContract call eth_call
 func (b *Bridge) CallContract(contract string, data hexutil.Bytes, blockNumber string) (string, error) {
 	reqArgs := map[string]interface{}{
@@ -578,12 +617,20 @@ func (b *Bridge) GetBaseFee(blockCount int) (*big.Int, error) {
 
 // EstimateGas call eth_estimateGas
 func (b *Bridge) EstimateGas(from, to string, value *big.Int, data []byte) (uint64, error) {
+	return b.EstimateGasWithAccessList(from, to, value, data, nil)
+}
+
+// EstimateGasWithAccessList call eth_estimateGas with access list
+func (b *Bridge) EstimateGasWithAccessList(from, to string, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
 	reqArgs := map[string]interface{}{
 		"from":  from,
 		"to":    to,
 		"value": (*hexutil.Big)(value),
 		"data":  hexutil.Bytes(data),
 	}
+	if len(accessList) > 0 {
+		reqArgs["accessList"] = accessList
+	}
 	gateway := b.GatewayConfig
 	var result hexutil.Uint64
 	var err error
@@ -593,7 +640,44 @@ func (b *Bridge) EstimateGas(from, to string, value *big.Int, data []byte) (uint
 		if err == nil {
 			return uint64(result), nil
 		}
+		if reason, isFailed := getExecutionFailedReason(err); isFailed {
+			log.Warn("[rpc] estimate gas failed as tx will revert", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "reason", reason)
+			return 0, fmt.Errorf("%w: %v", tokens.ErrSwapTxWillRevert, reason)
+		}
 	}
 	log.Warn("[rpc] estimate gas failed", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "err", err)
 	return 0, wrapRPCQueryError(err, "eth_estimateGas")
 }
+
+// CreateAccessList call eth_createAccessList
+func (b *Bridge) CreateAccessList(from, to string, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	reqArgs := map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"value": (*hexutil.Big)(value),
+		"data":  hexutil.Bytes(data),
+	}
+	gateway := b.GatewayConfig
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    hexutil.Uint64   `json:"gasUsed"`
+		Error      string           `json:"error,omitempty"`
+	}
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_createAccessList", reqArgs, "pending")
+		if err == nil {
+			if result.Error != "" {
+				return nil, 0, fmt.Errorf("%w: %v", tokens.ErrSwapTxWillRevert, result.Error)
+			}
+			return result.AccessList, uint64(result.GasUsed), nil
+		}
+		if reason, isFailed := getExecutionFailedReason(err); isFailed {
+			log.Warn("[rpc] create access list failed as tx will revert", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "reason", reason)
+			return nil, 0, fmt.Errorf("%w: %v", tokens.ErrSwapTxWillRevert, reason)
+		}
+	}
+	log.Warn("[rpc] create access list failed", "from", from, "to", to, "value", value, "data", hexutil.Bytes(data), "err", err)
+	return nil, 0, wrapRPCQueryError(err, "eth_createAccessList")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
 )
 
 // token types (should be all upper case)
@@ -50,6 +51,64 @@ func (b *Bridge) GetErc20Decimals(contract string) (uint8, error) {
 	return uint8(decimals), err
 }
 
+// GetErc20Name get erc20 name
+func (b *Bridge) GetErc20Name(contract string) (string, error) {
+	return b.getErc20String(contract, erc20CodeParts["name"])
+}
+
+// GetErc20Symbol get erc20 symbol
+func (b *Bridge) GetErc20Symbol(contract string) (string, error) {
+	return b.getErc20String(contract, erc20CodeParts["symbol"])
+}
+
+func (b *Bridge) getErc20String(contract string, funcHash []byte) (string, error) {
+	result, err := b.CallContract(contract, funcHash, "latest")
+	if err != nil {
+		return "", err
+	}
+	data := common.FromHex(result)
+	if len(data) == 32 { // some old tokens return bytes32 (eg. MKR)
+		return strings.TrimRight(string(data), "\x00"), nil
+	}
+	return abicoder.ParseStringInData(data, 0)
+}
+
+// GetProxyImplementation get implementation of EIP-1967 proxy contract,
+// return empty address if it's not a proxy
+func (b *Bridge) GetProxyImplementation(contract string) (common.Address, error) {
+	// bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1)
+	slot := common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	res, err := b.GetStorageAt(contract, slot, "latest")
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(res.Bytes()), nil
+}
+
+// HasMinterRole check account has minter role of mapping token contract by
+// `hasRole(MINTER_ROLE, account)`, `isMinter(account)` or `owner()`,
+// return the passed check method
+func (b *Bridge) HasMinterRole(contract, account string) (method string, err error) {
+	accountAddr := common.HexToAddress(account)
+	minterRole := common.Keccak256Hash([]byte("MINTER_ROLE"))
+	// keccak256 'hasRole(bytes32,address)' is '0x91d14854'
+	res, err := b.CallContract(contract, abicoder.PackDataWithFuncHash(common.FromHex("0x91d14854"), minterRole, accountAddr), "latest")
+	if err == nil && common.HexToHash(res) == common.BigToHash(big.NewInt(1)) {
+		return "hasRole", nil
+	}
+	// keccak256 'isMinter(address)' is '0xaa271e1a'
+	res, err = b.CallContract(contract, abicoder.PackDataWithFuncHash(common.FromHex("0xaa271e1a"), accountAddr), "latest")
+	if err == nil && common.HexToHash(res) == common.BigToHash(big.NewInt(1)) {
+		return "isMinter", nil
+	}
+	// keccak256 'owner()' is '0x8da5cb5b'
+	res, err = b.CallContract(contract, common.FromHex("0x8da5cb5b"), "latest")
+	if err == nil && common.HexToAddress(res) == accountAddr {
+		return "owner", nil
+	}
+	return "", fmt.Errorf("%v has no minter role of %v", account, contract)
+}
+
 // GetTokenBalance api
 func (b *Bridge) GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error) {
 	switch strings.ToUpper(tokenType) {
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+pragma solidity ^0.8.0;
+
+/// @notice Interface the destination mapping token must implement to support swap-and-call.
+/// The bridge oracles verify the runtime code contains the `SwapinAndCall` selector (0x10e73ac7).
+interface ISwapinAndCall {
+    /// @dev emitted when the swapin is minted to `target` and the call succeeds.
+    event LogSwapinAndCall(bytes32 indexed txhash, address indexed account, uint256 amount, address indexed target);
+
+    /// @dev emitted when the call to `target` reverts and the swapin is minted to `account` instead.
+    event LogSwapinCallFailed(bytes32 indexed txhash, address indexed account, uint256 amount, address indexed target);
+
+    /// @notice Mint `amount` to `target` and call it with `data`.
+    /// If the call reverts, the mint to `target` must be rolled back and `amount` minted to `account`.
+    /// Only callable by the bridge MPC address, and `txhash` must not be swapped before.
+    function SwapinAndCall(bytes32 txhash, address account, uint256 amount, address target, bytes calldata data) external returns (bool);
+}
+
+/// @notice Reference implementation of the call with fallback, for a token inheriting an ERC20 with
+/// internal `_mint`/`_burn` and the bridge `onlyAuth` and `swapinExisted` bookkeeping.
+abstract contract SwapinAndCallBase is ISwapinAndCall {
+    function _mint(address account, uint256 amount) internal virtual;
+
+    function _burn(address account, uint256 amount) internal virtual;
+
+    function _checkAndMarkSwapin(bytes32 txhash) internal virtual;
+
+    function SwapinAndCall(bytes32 txhash, address account, uint256 amount, address target, bytes calldata data) external virtual override returns (bool) {
+        _checkAndMarkSwapin(txhash);
+        _mint(target, amount);
+        (bool success, ) = target.call(data);
+        if (success) {
+            emit LogSwapinAndCall(txhash, account, amount, target);
+        } else {
+            _burn(target, amount);
+            _mint(account, amount);
+            emit LogSwapinCallFailed(txhash, account, amount, target);
+        }
+        return true;
+    }
+}
@@ -0,0 +1,198 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+var (
+	// first 4 bytes of `Keccak256Hash([]byte("flush(bytes32,address)"))`
+	// factory deploys forwarder if not exist and flush its balance to dcrm address
+	forwarderFlushFuncHash = common.FromHex("0xb026fd99")
+
+	errDepositForwarderNotSupported = errors.New("token does not support deposit forwarder")
+)
+
+// NormalizeBindAddress lower case hex address, keep case sensitive address (eg. base58) as is
+func NormalizeBindAddress(bindAddress string) string {
+	if common.IsHexAddress(bindAddress) {
+		return strings.ToLower(bindAddress)
+	}
+	return bindAddress
+}
+
+// GetDepositAddressSalt get CREATE2 salt of deposit address
+func GetDepositAddressSalt(pairID, bindAddress string) common.Hash {
+	return common.Keccak256Hash([]byte(strings.ToLower(pairID) + ":" + NormalizeBindAddress(bindAddress)))
+}
+
+// CalcCreate2Address calc address of contract created by CREATE2
+func CalcCreate2Address(factory common.Address, salt, initCodeHash common.Hash) common.Address {
+	hash := common.Keccak256Hash([]byte{0xff}, factory.Bytes(), salt.Bytes(), initCodeHash.Bytes())
+	return common.BytesToAddress(hash.Bytes()[12:])
+}
+
+// GetDepositAddress get per user deposit address of bind address
+func (b *Bridge) GetDepositAddress(pairID, bindAddress string) (*tokens.DepositAddressInfo, error) {
+	if !tokens.GetCrossChainBridge(!b.IsSrc).IsValidAddress(bindAddress) {
+		return nil, fmt.Errorf("invalid bind address %v", bindAddress)
+	}
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if !token.HasDepositForwarder() {
+		return nil, errDepositForwarderNotSupported
+	}
+	bindAddress = NormalizeBindAddress(bindAddress)
+	salt := GetDepositAddressSalt(pairID, bindAddress)
+	factory := common.HexToAddress(token.DepositForwarderFactory)
+	initCodeHash := common.HexToHash(token.DepositForwarderInitCodeHash)
+	depositAddress := CalcCreate2Address(factory, salt, initCodeHash)
+	return &tokens.DepositAddressInfo{
+		PairID:         strings.ToLower(pairID),
+		BindAddress:    bindAddress,
+		DepositAddress: strings.ToLower(depositAddress.String()),
+		Salt:           salt.String(),
+		Factory:        strings.ToLower(factory.String()),
+	}, nil
+}
+
+func (b *Bridge) getDepositAddressBind(token *tokens.TokenConfig, pairID, depositAddress string) string {
+	if !token.HasDepositForwarder() {
+		return ""
+	}
+	bindAddress := tools.GetDepositBindAddress(pairID, depositAddress)
+	if bindAddress == "" {
+		return ""
+	}
+	// recalc to prevent inconsistent records
+	info, err := b.GetDepositAddress(pairID, bindAddress)
+	if err != nil || !common.IsEqualIgnoreCase(info.DepositAddress, depositAddress) {
+		log.Warn("deposit address mismatch", "pairID", pairID, "depositAddress", depositAddress, "bindAddress", bindAddress)
+		return ""
+	}
+	return info.BindAddress
+}
+
+// ShouldSweepDepositAddress whether the deposit address has enough balance to sweep
+func (b *Bridge) ShouldSweepDepositAddress(pairID, depositAddress string) bool {
+	token := b.GetTokenConfig(pairID)
+	if token == nil || !token.HasDepositForwarder() {
+		return false
+	}
+	var balance *big.Int
+	var err error
+	if token.IsErc20() {
+		balance, err = b.GetErc20Balance(token.ContractAddress, depositAddress)
+	} else {
+		balance, err = b.GetBalance(depositAddress)
+	}
+	if err != nil {
+		log.Warn("get deposit address balance failed", "pairID", pairID, "depositAddress", depositAddress, "err", err)
+		return false
+	}
+	if balance.Sign() <= 0 {
+		return false
+	}
+	minValue := tokens.ToBits(*token.MinimumSwap, *token.Decimals)
+	return balance.Cmp(minValue) >= 0
+}
+
+// BuildSweepTransaction build tx to flush balance of deposit address to dcrm address.
+// it should be called in the serialized swapout task flow of the dcrm address
+// to prevent nonce collision with swapout txs.
+func (b *Bridge) BuildSweepTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	args.Identifier = tokens.SweepIdentifier
+	args.From = token.DcrmAddress
+	err = b.buildSweepTxInput(args)
+	if err != nil {
+		return nil, err
+	}
+	extra := getOrInitExtra(args)
+	if extra.Nonce == nil {
+		extra.Nonce, err = b.getAccountNonce(args)
+		if err != nil {
+			return nil, err
+		}
+		*extra.Nonce = b.AdjustNonce(args.PairID, *extra.Nonce)
+	}
+	return b.buildNonswapTx(args)
+}
+
+// build input for calling `flush(bytes32 salt, address token)` of forwarder factory
+func (b *Bridge) buildSweepTxInput(args *tokens.BuildTxArgs) error {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if !token.HasDepositForwarder() {
+		return errDepositForwarderNotSupported
+	}
+	var tokenAddress common.Address
+	if token.IsErc20() {
+		tokenAddress = common.HexToAddress(token.ContractAddress)
+	}
+	salt := GetDepositAddressSalt(args.PairID, args.Bind)
+	input := abicoder.PackDataWithFuncHash(forwarderFlushFuncHash, salt, tokenAddress)
+	args.Input = &input                     // input
+	args.To = token.DepositForwarderFactory // to
+	return nil
+}
+
+// VerifySweepMsgHash verify sweep msgHash
+func (b *Bridge) VerifySweepMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
+	if args == nil || args.Extra == nil || args.Extra.EthExtra == nil {
+		return errors.New("empty eth extra")
+	}
+	extra := args.Extra.EthExtra
+	if extra.Gas == nil || extra.Nonce == nil {
+		return errors.New("empty gas or nonce")
+	}
+	maxGasPrice := b.ChainConfig.GetMaxGasPrice()
+	if maxGasPrice != nil {
+		for _, price := range []*big.Int{extra.GasPrice, extra.GasFeeCap} {
+			if price != nil && price.Cmp(maxGasPrice) > 0 {
+				return fmt.Errorf("gas price %v exceeded maximum limit", price)
+			}
+		}
+	}
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	// only sweep registered deposit address
+	info, err := b.GetDepositAddress(args.PairID, args.Bind)
+	if err != nil {
+		return err
+	}
+	if b.getDepositAddressBind(token, args.PairID, info.DepositAddress) != info.BindAddress {
+		return fmt.Errorf("deposit address %v of bind %v is not registered", info.DepositAddress, args.Bind)
+	}
+	buildArgs := &tokens.BuildTxArgs{
+		SwapInfo: args.SwapInfo,
+		From:     token.DcrmAddress,
+		Extra:    args.Extra,
+	}
+	err = b.buildSweepTxInput(buildArgs)
+	if err != nil {
+		return err
+	}
+	rawTx, err := b.buildNonswapTx(buildArgs)
+	if err != nil {
+		return err
+	}
+	return b.VerifyMsgHash(rawTx, msgHash)
+}
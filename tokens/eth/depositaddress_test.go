@@ -0,0 +1,58 @@
+package eth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+// test cases from EIP-1014
+func TestCalcCreate2Address(t *testing.T) {
+	testCases := []struct {
+		factory  string
+		salt     string
+		initCode string
+		want     string
+	}{
+		{
+			factory:  "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "0x00",
+			want:     "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			factory:  "0xdeadbeef00000000000000000000000000000000",
+			salt:     "0x000000000000000000000000feed000000000000000000000000000000000000",
+			initCode: "0x00",
+			want:     "0xD04116cDd17beBE565EB2422F2497E06cC1C9833",
+		},
+		{
+			factory:  "0x00000000000000000000000000000000deadbeef",
+			salt:     "0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			initCode: "0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			want:     "0x1d8bfDC5D46DC4f61D6b6115972536eBE6A8854C",
+		},
+	}
+	for i, tc := range testCases {
+		initCodeHash := common.Keccak256Hash(common.FromHex(tc.initCode))
+		have := CalcCreate2Address(common.HexToAddress(tc.factory), common.HexToHash(tc.salt), initCodeHash)
+		if have != common.HexToAddress(tc.want) {
+			t.Errorf("test case %v failed, want %v, have %v", i, tc.want, have.String())
+		}
+	}
+}
+
+func TestGetDepositAddressSalt(t *testing.T) {
+	hexBind := "0x0F5EDfA8E3a1aE2F7b7F6fB8e5D2d1C3b4A5e6F7"
+	if GetDepositAddressSalt("ETH", hexBind) != GetDepositAddressSalt("eth", strings.ToLower(hexBind)) {
+		t.Errorf("salt of hex bind address should be case insensitive")
+	}
+	base58Bind := "rLHzPsX6oXkzU2qL12kHCH8G8cnZv1rBJh"
+	if GetDepositAddressSalt("xrp", base58Bind) == GetDepositAddressSalt("xrp", strings.ToLower(base58Bind)) {
+		t.Errorf("salt of case sensitive bind address should be case sensitive")
+	}
+	if have := NormalizeBindAddress(base58Bind); have != base58Bind {
+		t.Errorf("normalize base58 address mismatch, want %v, have %v", base58Bind, have)
+	}
+}
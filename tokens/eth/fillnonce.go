@@ -0,0 +1,90 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const fillNonceGasLimit = uint64(21000)
+
+// BuildFillNonceTransaction build zero value self transfer tx to fill nonce gap
+func (b *Bridge) BuildFillNonceTransaction(pairID string, nonce uint64) (rawTx interface{}, args *tokens.BuildTxArgs, err error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, nil, tokens.ErrUnknownPairID
+	}
+	swapType := tokens.SwapinType
+	if b.IsSrcEndpoint() {
+		swapType = tokens.SwapoutType
+	}
+	gasLimit := fillNonceGasLimit
+	args = &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			Identifier: tokens.FillNonceIdentifier,
+			PairID:     pairID,
+			SwapType:   swapType,
+		},
+		From:  token.DcrmAddress,
+		To:    token.DcrmAddress,
+		Value: big.NewInt(0),
+		Extra: &tokens.AllExtras{
+			EthExtra: &tokens.EthExtraArgs{
+				Gas:   &gasLimit,
+				Nonce: &nonce,
+			},
+		},
+	}
+	rawTx, err = b.buildNonswapTx(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawTx, args, nil
+}
+
+// VerifyFillNonceMsgHash verify fill nonce msgHash
+func (b *Bridge) VerifyFillNonceMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
+	if args == nil || args.Extra == nil || args.Extra.EthExtra == nil {
+		return errors.New("empty eth extra")
+	}
+	extra := args.Extra.EthExtra
+	if extra.Gas == nil || extra.Nonce == nil {
+		return errors.New("empty gas or nonce")
+	}
+	if *extra.Gas != fillNonceGasLimit {
+		return fmt.Errorf("fill nonce with wrong gas limit %v", *extra.Gas)
+	}
+	maxGasPrice := b.ChainConfig.GetMaxGasPrice()
+	if maxGasPrice != nil {
+		for _, price := range []*big.Int{extra.GasPrice, extra.GasFeeCap} {
+			if price != nil && price.Cmp(maxGasPrice) > 0 {
+				return fmt.Errorf("gas price %v exceeded maximum limit", price)
+			}
+		}
+	}
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	latestNonce, err := b.GetPoolNonce(token.DcrmAddress, "latest")
+	if err != nil {
+		return err
+	}
+	if *extra.Nonce < latestNonce {
+		return fmt.Errorf("fill nonce %v is lower than latest nonce %v", *extra.Nonce, latestNonce)
+	}
+	buildArgs := &tokens.BuildTxArgs{
+		SwapInfo: args.SwapInfo,
+		From:     token.DcrmAddress,
+		To:       token.DcrmAddress,
+		Value:    big.NewInt(0),
+		Extra:    args.Extra,
+	}
+	rawTx, err := b.buildNonswapTx(buildArgs)
+	if err != nil {
+		return err
+	}
+	return b.VerifyMsgHash(rawTx, msgHash)
+}
@@ -0,0 +1,71 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var errNoGasAirdrop = errors.New("token has no gas airdrop")
+
+func getGasAirdropKey(token *tokens.TokenConfig) (*ecdsa.PrivateKey, error) {
+	cfg := token.GasAirdrop
+	if cfg == nil || cfg.KeystoreFile == "" {
+		return nil, errNoGasAirdrop
+	}
+	return loadPrivateKey(cfg.KeystoreFile, cfg.PasswordFile, cfg.SenderAddress)
+}
+
+// ShouldAirdropGas whether native balance of bind address is below airdrop threshold
+func (b *Bridge) ShouldAirdropGas(pairID, bind string) (bool, error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil || token.GasAirdrop == nil {
+		return false, errNoGasAirdrop
+	}
+	balance, err := b.GetBalance(bind)
+	if err != nil {
+		return false, err
+	}
+	return token.GasAirdrop.IsBelowBalanceThreshold(balance), nil
+}
+
+// AirdropGas send native gas airdrop to bind address from airdrop sender.
+// airdrop sender has its own nonce which is independent of dcrm address.
+func (b *Bridge) AirdropGas(pairID, bind string) (txHash string, err error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil || token.GasAirdrop == nil {
+		return "", errNoGasAirdrop
+	}
+	if !b.IsValidAddress(bind) {
+		return "", tokens.ErrTxWithWrongMemo
+	}
+	privKey, err := getGasAirdropKey(token)
+	if err != nil {
+		return "", err
+	}
+	cfg := token.GasAirdrop
+	buildArgs := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{PairID: pairID},
+		From:     cfg.SenderAddress,
+		To:       bind,
+		Value:    tokens.ToBits(cfg.Amount, 18),
+	}
+	rawTx, err := b.BuildRawTransaction(buildArgs)
+	if err != nil {
+		return "", err
+	}
+	signedTx, txHash, err := b.SignTransactionWithPrivateKey(rawTx, privKey)
+	if err != nil {
+		return "", err
+	}
+	_, err = b.SendTransaction(signedTx)
+	if err != nil {
+		return "", err
+	}
+	log.Info("send gas airdrop success", "pairID", pairID, "bind", bind, "value", buildArgs.Value,
+		"txHash", txHash, "nonce", rawTx.(*types.Transaction).Nonce())
+	return txHash, nil
+}
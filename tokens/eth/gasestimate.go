@@ -0,0 +1,159 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var defaultEstimateGasMargin = uint64(30) // percentage
+
+func (b *Bridge) getEstimateGasMargin() uint64 {
+	if b.ChainConfig.EstimateGasMargin > 0 {
+		return b.ChainConfig.EstimateGasMargin
+	}
+	return defaultEstimateGasMargin
+}
+
+// simulateTx simulate tx by `eth_createAccessList` (if access list is enabled)
+// and `eth_estimateGas`, return the estimated gas (without safety margin)
+func (b *Bridge) simulateTx(from, to string, value *big.Int, input []byte) (estimatedGas uint64, accessList types.AccessList, err error) {
+	if b.ChainConfig.EnableAccessList {
+		accessList, _, err = b.CreateAccessList(from, to, value, input)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	estimatedGas, err = b.EstimateGasWithAccessList(from, to, value, input, accessList)
+	if err != nil {
+		return 0, nil, err
+	}
+	return estimatedGas, accessList, nil
+}
+
+// calcGasLimit add safety margin to estimated gas, the result is
+// not less than `DefaultGasLimit` and not greater than `MaxGasLimit` of token
+func (b *Bridge) calcGasLimit(pairID string, estimatedGas uint64) (uint64, error) {
+	gasLimit := estimatedGas + estimatedGas*b.getEstimateGasMargin()/100
+	defGasLimit := b.getDefaultGasLimit(pairID)
+	if gasLimit < defGasLimit {
+		gasLimit = defGasLimit
+	}
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg != nil && tokenCfg.MaxGasLimit > 0 {
+		if estimatedGas > tokenCfg.MaxGasLimit {
+			return 0, fmt.Errorf("estimated gas %v exceeds max gas limit %v", estimatedGas, tokenCfg.MaxGasLimit)
+		}
+		if gasLimit > tokenCfg.MaxGasLimit {
+			gasLimit = tokenCfg.MaxGasLimit
+		}
+	}
+	return gasLimit, nil
+}
+
+func (b *Bridge) setDefaultGasLimit(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs) error {
+	var input []byte
+	if args.Input != nil {
+		input = *args.Input
+	}
+
+	estimatedGas, accessList, err := b.simulateTx(args.From, args.To, args.Value, input)
+	if err != nil {
+		log.Error(fmt.Sprintf("build %s tx estimate gas failed", args.SwapType.String()),
+			"swapID", args.SwapID, "from", args.From, "to", args.To,
+			"value", args.Value, "data", common.ToHex(input), "err", err)
+		if errors.Is(err, tokens.ErrSwapTxWillRevert) {
+			return err // keep revert reason
+		}
+		return tokens.ErrEstimateGasFailed
+	}
+
+	gasLimit, err := b.calcGasLimit(args.PairID, estimatedGas)
+	if err != nil {
+		log.Error(fmt.Sprintf("build %s tx calc gas limit failed", args.SwapType.String()),
+			"swapID", args.SwapID, "estimatedGas", estimatedGas, "err", err)
+		return err
+	}
+	extra.Gas = &gasLimit
+	extra.AccessList = accessList
+	return nil
+}
+
+// VerifySwapTxGas verify gas limit and access list of swap tx by own simulation,
+// the gas limit must be enough for the simulated gas and not exceed
+// the gas limit calced with twice safety margin, and the access list
+// must be a subset of the simulated access list.
+// the simulation is always done by oracle, and does not trust any
+// gas estimation provided by the server.
+func (b *Bridge) VerifySwapTxGas(rawTx interface{}, args *tokens.BuildTxArgs) error {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok || tx.To() == nil {
+		return tokens.ErrWrongRawTx
+	}
+	to := tx.To().String()
+	value := tx.Value()
+	input := tx.Data()
+	txAccessList := tx.AccessList()
+
+	if len(txAccessList) > 0 {
+		accessList, _, err := b.CreateAccessList(args.From, to, value, input)
+		if err != nil {
+			return err
+		}
+		if !isAccessListSubset(txAccessList, accessList) {
+			return fmt.Errorf("access list is not subset of simulated access list")
+		}
+	}
+
+	estimatedGas, err := b.EstimateGasWithAccessList(args.From, to, value, input, txAccessList)
+	if err != nil {
+		return err
+	}
+	maxGasLimit, err := b.calcGasLimit(args.PairID, estimatedGas+estimatedGas*b.getEstimateGasMargin()/100)
+	if err != nil {
+		return err
+	}
+	return checkGasLimit(tx.Gas(), estimatedGas, maxGasLimit)
+}
+
+// checkGasLimit check gas limit is in range [estimatedGas, maxGasLimit]
+func checkGasLimit(gasLimit, estimatedGas, maxGasLimit uint64) error {
+	if gasLimit < estimatedGas {
+		return fmt.Errorf("gas limit %v is less than simulated gas %v", gasLimit, estimatedGas)
+	}
+	if gasLimit > maxGasLimit {
+		return fmt.Errorf("gas limit %v exceeds %v (simulated gas %v)", gasLimit, maxGasLimit, estimatedGas)
+	}
+	return nil
+}
+
+func isAccessListSubset(accessList, superList types.AccessList) bool {
+	storageKeys := make(map[common.Address]map[common.Hash]struct{}, len(superList))
+	for _, tuple := range superList {
+		keys, exist := storageKeys[tuple.Address]
+		if !exist {
+			keys = make(map[common.Hash]struct{}, len(tuple.StorageKeys))
+			storageKeys[tuple.Address] = keys
+		}
+		for _, key := range tuple.StorageKeys {
+			keys[key] = struct{}{}
+		}
+	}
+	for _, tuple := range accessList {
+		keys, exist := storageKeys[tuple.Address]
+		if !exist {
+			return false
+		}
+		for _, key := range tuple.StorageKeys {
+			if _, exist := keys[key]; !exist {
+				return false
+			}
+		}
+	}
+	return true
+}
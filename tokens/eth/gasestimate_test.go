@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestIsAccessListSubset(t *testing.T) {
+	addr1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	addr2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	key1 := common.HexToHash("0x01")
+	key2 := common.HexToHash("0x02")
+
+	superList := types.AccessList{
+		{Address: addr1, StorageKeys: []common.Hash{key1, key2}},
+		{Address: addr2},
+	}
+
+	tests := []struct {
+		accessList types.AccessList
+		isSubset   bool
+	}{
+		{nil, true},
+		{superList, true},
+		{types.AccessList{{Address: addr1, StorageKeys: []common.Hash{key2}}}, true},
+		{types.AccessList{{Address: addr2}}, true},
+		{types.AccessList{{Address: addr2, StorageKeys: []common.Hash{key1}}}, false},
+		{types.AccessList{{Address: common.HexToAddress("0x3333333333333333333333333333333333333333")}}, false},
+	}
+	for i, test := range tests {
+		if have := isAccessListSubset(test.accessList, superList); have != test.isSubset {
+			t.Errorf("test %v: want %v, have %v", i, test.isSubset, have)
+		}
+	}
+}
+
+func TestCheckGasLimit(t *testing.T) {
+	tests := []struct {
+		gasLimit     uint64
+		estimatedGas uint64
+		maxGasLimit  uint64
+		wantErr      bool
+	}{
+		{gasLimit: 100000, estimatedGas: 80000, maxGasLimit: 128000, wantErr: false},
+		{gasLimit: 80000, estimatedGas: 80000, maxGasLimit: 128000, wantErr: false},
+		{gasLimit: 128000, estimatedGas: 80000, maxGasLimit: 128000, wantErr: false},
+		{gasLimit: 79999, estimatedGas: 80000, maxGasLimit: 128000, wantErr: true},
+		{gasLimit: 128001, estimatedGas: 80000, maxGasLimit: 128000, wantErr: true},
+		{gasLimit: 8000000, estimatedGas: 80000, maxGasLimit: 128000, wantErr: true},
+	}
+	for i, test := range tests {
+		err := checkGasLimit(test.gasLimit, test.estimatedGas, test.maxGasLimit)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: gas limit %v, want error %v, have %v", i, test.gasLimit, test.wantErr, err)
+		}
+	}
+}
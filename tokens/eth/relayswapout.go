@@ -0,0 +1,303 @@
+package eth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var (
+	// first 4 bytes of `Keccak256Hash([]byte("SwapoutWithPermit(address,uint256,string,uint256,uint8,bytes32,bytes32)"))`
+	mBTCSwapoutWithPermitFuncHash = common.FromHex("0xa252553d")
+	// first 4 bytes of `Keccak256Hash([]byte("SwapoutWithPermit(address,uint256,address,uint256,uint8,bytes32,bytes32)"))`
+	mETHSwapoutWithPermitFuncHash = common.FromHex("0xd93affca")
+
+	// EIP-712 type hash of swapout permit signed by owner
+	swapoutPermitTypeHash         = common.Keccak256Hash([]byte("SwapoutPermit(address owner,uint256 amount,address bind,uint256 nonce,uint256 deadline)"))
+	swapoutToStringPermitTypeHash = common.Keccak256Hash([]byte("SwapoutPermit(address owner,uint256 amount,string bind,uint256 nonce,uint256 deadline)"))
+	// first 4 bytes of `Keccak256Hash([]byte("DOMAIN_SEPARATOR()"))`
+	domainSeparatorFuncHash = common.FromHex("0x3644e515")
+	// first 4 bytes of `Keccak256Hash([]byte("nonces(address)"))`
+	permitNoncesFuncHash = common.FromHex("0x7ecebe00")
+
+	// serialize relaying to prevent nonce conflict of relayer
+	relayLock sync.Mutex
+
+	// gas limit cap of one relayed swapout tx
+	maxRelayGasLimit uint64 = 300000
+	// min interval seconds of relaying for the same owner
+	relayOwnerInterval = int64(60)
+	// max relays of all owners in one minute
+	maxRelaysPerMinute = 30
+
+	// rate limit records, guarded by relayLock
+	relayedOwners         = make(map[string]int64) // owner -> last relay time
+	recentRelays  []int64                          // relay times in last minute
+
+	errNoRelayer            = errors.New("token has no relayer")
+	errWrongSignature       = errors.New("wrong signature")
+	errSignedSwapoutExpired = errors.New("signed swapout is expired")
+	errRelayFeeTooLarge     = errors.New("relay fee is not less than swapout amount")
+	errRelayGasTooLarge     = errors.New("relay gas limit exceeds cap")
+	errRelayRateLimited     = errors.New("relay is rate limited, please retry later")
+)
+
+func getSwapoutWithPermitFuncHash() []byte {
+	if isSwapoutToStringAddress() {
+		return mBTCSwapoutWithPermitFuncHash
+	}
+	return mETHSwapoutWithPermitFuncHash
+}
+
+func getRelayerKey(token *tokens.TokenConfig) (*ecdsa.PrivateKey, error) {
+	if token.RelayerKeystoreFile == "" {
+		return nil, errNoRelayer
+	}
+	return loadPrivateKey(token.RelayerKeystoreFile, token.RelayerPasswordFile, token.RelayerAddress)
+}
+
+// calcRelayFee convert relay gas cost to token amount
+func calcRelayFee(token *tokens.TokenConfig, gasCost *big.Int) *big.Int {
+	rate := tokens.ToBits(token.RelayGasTokenRate, *token.Decimals)
+	fee := new(big.Int).Mul(gasCost, rate)
+	return fee.Div(fee, big.NewInt(1e18))
+}
+
+// getSwapoutPermitDigest get EIP-712 digest of swapout permit
+func getSwapoutPermitDigest(domainSeparator common.Hash, owner common.Address, amount *big.Int, bind string, nonce *big.Int, deadline uint64) common.Hash {
+	var structData []byte
+	if isSwapoutToStringAddress() {
+		structData = abicoder.PackData(swapoutToStringPermitTypeHash, owner, amount,
+			common.Keccak256Hash([]byte(bind)), nonce, deadline)
+	} else {
+		structData = abicoder.PackData(swapoutPermitTypeHash, owner, amount,
+			common.HexToAddress(bind), nonce, deadline)
+	}
+	structHash := common.Keccak256Hash(structData)
+	return common.Keccak256Hash([]byte("\x19\x01"), domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// verifySwapoutPermitSigner verify the permit is signed by owner
+func verifySwapoutPermitSigner(digest common.Hash, owner common.Address, sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return errWrongSignature
+	}
+	recSig := make([]byte, crypto.SignatureLength)
+	copy(recSig, sig)
+	if recSig[64] >= 27 {
+		recSig[64] -= 27
+	}
+	pubkey, err := crypto.SigToPub(digest.Bytes(), recSig)
+	if err != nil || crypto.PubkeyToAddress(*pubkey) != owner {
+		return errWrongSignature
+	}
+	return nil
+}
+
+// getSwapoutPermitDigestOnChain get permit digest with domain separator and nonce of token contract
+func (b *Bridge) getSwapoutPermitDigestOnChain(contract string, owner common.Address, amount *big.Int, bind string, deadline uint64) (common.Hash, error) {
+	result, err := b.CallContract(contract, domainSeparatorFuncHash, "latest")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	domainSeparator := common.HexToHash(result)
+	result, err = b.CallContract(contract, abicoder.PackDataWithFuncHash(permitNoncesFuncHash, owner), "latest")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	nonce, err := common.GetBigIntFromStr(result)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return getSwapoutPermitDigest(domainSeparator, owner, amount, bind, nonce, deadline), nil
+}
+
+// checkRelayRateLimit limit relays of the same owner and of all owners
+// (caller should hold relayLock)
+func checkRelayRateLimit(owner string, nowTime int64) error {
+	for len(recentRelays) > 0 && recentRelays[0] <= nowTime-60 {
+		recentRelays = recentRelays[1:]
+	}
+	if len(recentRelays) >= maxRelaysPerMinute {
+		return errRelayRateLimited
+	}
+	if lastTime, exist := relayedOwners[owner]; exist && lastTime+relayOwnerInterval > nowTime {
+		return errRelayRateLimited
+	}
+	return nil
+}
+
+// recordRelay record relay for rate limit (caller should hold relayLock)
+func recordRelay(owner string, nowTime int64) {
+	for key, lastTime := range relayedOwners {
+		if lastTime+relayOwnerInterval <= nowTime {
+			delete(relayedOwners, key)
+		}
+	}
+	relayedOwners[owner] = nowTime
+	recentRelays = append(recentRelays, nowTime)
+}
+
+// RelaySignedSwapout send `SwapoutWithPermit` tx of signed swapout from relayer
+func (b *Bridge) RelaySignedSwapout(args *tokens.SignedSwapoutArgs) (*tokens.TxSwapInfo, error) {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if token.DisableSwap {
+		return nil, tokens.ErrSwapIsClosed
+	}
+	if b.IsSrc || token.RelayerAddress == "" {
+		return nil, errNoRelayer
+	}
+	privKey, err := getRelayerKey(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !common.IsHexAddress(args.Owner) {
+		return nil, fmt.Errorf("wrong owner address '%v'", args.Owner)
+	}
+	if !tokens.SrcBridge.IsValidAddress(args.Bind) {
+		return nil, tokens.ErrTxWithWrongMemo
+	}
+	amount, err := common.GetBigIntFromStr(args.Amount)
+	if err != nil || amount.Sign() <= 0 {
+		return nil, tokens.ErrTxWithWrongValue
+	}
+	if args.Deadline <= uint64(time.Now().Unix()) {
+		return nil, errSignedSwapoutExpired
+	}
+	sig := common.FromHex(args.Signature)
+	if len(sig) != crypto.SignatureLength {
+		return nil, errWrongSignature
+	}
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+
+	owner := common.HexToAddress(args.Owner)
+	digest, err := b.getSwapoutPermitDigestOnChain(token.ContractAddress, owner, amount, args.Bind, args.Deadline)
+	if err != nil {
+		return nil, err
+	}
+	err = verifySwapoutPermitSigner(digest, owner, sig)
+	if err != nil {
+		return nil, err
+	}
+	err = b.checkBalance(token.ContractAddress, owner.String(), amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var bind interface{} = common.HexToAddress(args.Bind)
+	if isSwapoutToStringAddress() {
+		bind = args.Bind
+	}
+	input := abicoder.PackDataWithFuncHash(getSwapoutWithPermitFuncHash(),
+		owner, amount, bind, args.Deadline, uint64(v),
+		common.BytesToHash(sig[:32]), common.BytesToHash(sig[32:64]))
+
+	relayLock.Lock()
+	defer relayLock.Unlock()
+
+	ownerKey := strings.ToLower(owner.String())
+	nowTime := time.Now().Unix()
+	err = checkRelayRateLimit(ownerKey, nowTime)
+	if err != nil {
+		return nil, err
+	}
+
+	buildArgs := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{PairID: args.PairID},
+		From:     token.RelayerAddress,
+		To:       token.ContractAddress,
+		Input:    &input,
+	}
+	rawTx, err := b.BuildRawTransaction(buildArgs)
+	if err != nil {
+		return nil, err
+	}
+	tx := rawTx.(*types.Transaction)
+	if tx.Gas() > maxRelayGasLimit {
+		log.Warn("relay signed swapout failed", "pairID", args.PairID, "owner", args.Owner, "gas", tx.Gas(), "cap", maxRelayGasLimit)
+		return nil, errRelayGasTooLarge
+	}
+	gasPrice := tx.GasFeeCap()
+	if gasPrice == nil {
+		gasPrice = tx.GasPrice()
+	}
+	gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas()))
+	if relayFee := calcRelayFee(token, gasCost); relayFee.Cmp(amount) >= 0 {
+		log.Warn("relay signed swapout failed", "pairID", args.PairID, "owner", args.Owner, "amount", amount, "relayFee", relayFee)
+		return nil, errRelayFeeTooLarge
+	}
+	// do not waste relayer's gas on reverting tx
+	err = b.SimulateSwapTx(rawTx, buildArgs)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, txHash, err := b.SignTransactionWithPrivateKey(rawTx, privKey)
+	if err != nil {
+		return nil, err
+	}
+	_, err = b.SendTransaction(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	recordRelay(ownerKey, nowTime)
+	log.Info("relay signed swapout success", "pairID", args.PairID, "owner", args.Owner,
+		"bind", args.Bind, "amount", amount, "txHash", txHash, "nonce", tx.Nonce())
+
+	return &tokens.TxSwapInfo{
+		PairID: args.PairID,
+		Hash:   strings.ToLower(txHash),
+		From:   strings.ToLower(owner.String()),
+		TxTo:   strings.ToLower(token.ContractAddress),
+		To:     strings.ToLower(token.ContractAddress),
+		Bind:   args.Bind,
+		Value:  amount,
+	}, nil
+}
+
+// verifyRelayedSwapout set owner as swap sender and deduct relay fee from swap value
+func (b *Bridge) verifyRelayedSwapout(swapInfo *tokens.TxSwapInfo, token *tokens.TokenConfig, receipt *types.RPCTxReceipt) error {
+	tx, err := getTxByHash(b, swapInfo.Hash, true)
+	if err != nil {
+		return tokens.ErrTxNotFound
+	}
+	if tx.Payload == nil || !bytes.HasPrefix(*tx.Payload, getSwapoutWithPermitFuncHash()) || len(*tx.Payload) < 36 {
+		return tokens.ErrTxWithWrongInput
+	}
+	owner := common.BytesToAddress(common.GetData(*tx.Payload, 4, 32))
+	swapInfo.From = strings.ToLower(owner.String()) // From
+
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = tx.Price
+	}
+	if gasPrice == nil || receipt.GasUsed == nil {
+		return tokens.ErrTxWithWrongReceipt
+	}
+	gasCost := new(big.Int).Mul(gasPrice.ToInt(), new(big.Int).SetUint64(uint64(*receipt.GasUsed)))
+	relayFee := calcRelayFee(token, gasCost)
+	if relayFee.Cmp(swapInfo.Value) >= 0 {
+		return tokens.ErrTxWithWrongValue
+	}
+	swapInfo.Value = new(big.Int).Sub(swapInfo.Value, relayFee) // Value
+	log.Debug("deduct relay fee of swapout", "txid", swapInfo.Hash, "owner", swapInfo.From, "relayFee", relayFee, "value", swapInfo.Value)
+	return nil
+}
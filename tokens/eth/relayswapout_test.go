@@ -0,0 +1,72 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func TestVerifySwapoutPermitSigner(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := crypto.PubkeyToAddress(privKey.PublicKey)
+	domainSeparator := common.HexToHash("0x1234")
+	bind := "0x1111111111111111111111111111111111111111"
+	amount := big.NewInt(1000)
+	nonce := big.NewInt(5)
+	deadline := uint64(1700000000)
+
+	digest := getSwapoutPermitDigest(domainSeparator, owner, amount, bind, nonce, deadline)
+	sig, err := crypto.Sign(digest.Bytes(), privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[64] += 27 // wallets sign with v of 27 or 28
+	if err = verifySwapoutPermitSigner(digest, owner, sig); err != nil {
+		t.Errorf("verify permit signer failed: %v", err)
+	}
+
+	otherDigests := []common.Hash{
+		getSwapoutPermitDigest(domainSeparator, owner, amount, bind, big.NewInt(6), deadline),
+		getSwapoutPermitDigest(domainSeparator, owner, amount, bind, nonce, deadline+1),
+		getSwapoutPermitDigest(domainSeparator, owner, big.NewInt(1001), bind, nonce, deadline),
+		getSwapoutPermitDigest(common.HexToHash("0x5678"), owner, amount, bind, nonce, deadline),
+	}
+	for i, other := range otherDigests {
+		if err = verifySwapoutPermitSigner(other, owner, sig); err == nil {
+			t.Errorf("test %v: verify permit with other params should fail", i)
+		}
+	}
+	otherOwner := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	if err = verifySwapoutPermitSigner(digest, otherOwner, sig); err == nil {
+		t.Errorf("verify permit of other owner should fail")
+	}
+}
+
+func TestCheckRelayRateLimit(t *testing.T) {
+	defer func() {
+		relayedOwners = make(map[string]int64)
+		recentRelays = nil
+	}()
+	nowTime := int64(1700000000)
+	if err := checkRelayRateLimit("owner1", nowTime); err != nil {
+		t.Fatalf("first relay should pass: %v", err)
+	}
+	recordRelay("owner1", nowTime)
+	if err := checkRelayRateLimit("owner1", nowTime+1); err == nil {
+		t.Errorf("relay of the same owner in interval should be limited")
+	}
+	for i := 1; i < maxRelaysPerMinute; i++ {
+		recordRelay("other", nowTime)
+	}
+	if err := checkRelayRateLimit("owner2", nowTime+1); err == nil {
+		t.Errorf("relays exceed max per minute should be limited")
+	}
+	if err := checkRelayRateLimit("owner1", nowTime+relayOwnerInterval); err != nil {
+		t.Errorf("relay of the same owner after interval should pass: %v", err)
+	}
+}
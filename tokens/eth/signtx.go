@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools"
 	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/anyswap/CrossChain-Bridge/tools/keystore"
 	"github.com/anyswap/CrossChain-Bridge/types"
 )
 
@@ -31,6 +34,12 @@ func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.Build
 	if args.SwapType == tokens.SwapoutType && !tokenCfg.IsErc20() {
 		checkReceiver = args.Bind
 	}
+	switch args.Identifier {
+	case tokens.SweepIdentifier:
+		checkReceiver = tokenCfg.DepositForwarderFactory
+	case tokens.FillNonceIdentifier:
+		checkReceiver = tokenCfg.DcrmAddress
+	}
 	if !strings.EqualFold(tx.To().String(), checkReceiver) {
 		return nil, fmt.Errorf("[sign] verify tx receiver failed")
 	}
@@ -57,7 +66,7 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 	msgContext := string(jsondata)
 
 	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash.String(), "txid", args.SwapID)
-	keyID, rsvs, err := dcrm.DoSignOneEC(b.GetDcrmPublicKey(args.PairID), msgHash.String(), msgContext)
+	keyID, rsvs, err := tokens.DoSign(b.GetTokenConfig(args.PairID), dcrm.SignTypeEC256K1, b.GetDcrmPublicKey(args.PairID), []string{msgHash.String()}, []string{msgContext})
 	if err != nil {
 		log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction failed", "keyID", keyID, "msghash", msgHash.String(), "txid", args.SwapID, "err", err)
 		return nil, "", err
@@ -123,6 +132,29 @@ func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx inter
 	return b.SignTransactionWithPrivateKey(rawTx, ecPrikey)
 }
 
+// keystore file -> *keystore.Key
+var loadedKeystores sync.Map
+
+// loadPrivateKey load private key from keystore file (cached),
+// and check the key is of the configed address
+func loadPrivateKey(keystoreFile, passwordFile, address string) (*ecdsa.PrivateKey, error) {
+	var key *keystore.Key
+	if cached, exist := loadedKeystores.Load(keystoreFile); exist {
+		key = cached.(*keystore.Key)
+	} else {
+		loaded, err := tools.LoadKeyStore(keystoreFile, passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		loadedKeystores.Store(keystoreFile, loaded)
+		key = loaded
+	}
+	if !common.IsEqualIgnoreCase(key.Address.String(), address) {
+		return nil, fmt.Errorf("keystore %v mismatch address %v", keystoreFile, address)
+	}
+	return key.PrivateKey, nil
+}
+
 // SignTransactionWithPrivateKey sign tx with ECDSA private key
 func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa.PrivateKey) (signTx interface{}, txHash string, err error) {
 	tx, ok := rawTx.(*types.Transaction)
@@ -152,7 +184,73 @@ func (b *Bridge) CalcTransactionHash(tx *types.Transaction) (txHash string, err
 	return tx.Hash().Hex(), nil
 }
 
+// DcrmSignTransactions dcrm sign raw txs in one sign request (batch sign)
+// batchArgs.Batch are the extra args of raw txs in the same order
+func (b *Bridge) DcrmSignTransactions(rawTxs []interface{}, batchArgs *tokens.BuildTxArgs) (signTxs []interface{}, txHashes []string, err error) {
+	if len(rawTxs) == 0 || len(rawTxs) != len(batchArgs.Batch) {
+		return nil, nil, fmt.Errorf("batch sign with wrong number of txs %v and args %v", len(rawTxs), len(batchArgs.Batch))
+	}
+	pairID := batchArgs.Batch[0].PairID
+	txs := make([]*types.Transaction, len(rawTxs))
+	msgHashes := make([]string, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		txs[i], err = b.verifyTransactionWithArgs(rawTx, batchArgs.Batch[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		msgHashes[i] = b.Signer.Hash(txs[i]).String()
+	}
+	jsondata, _ := json.Marshal(batchArgs)
+	msgContext := string(jsondata)
+
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransactions start", "msghashes", msgHashes, "count", len(msgHashes))
+	keyID, rsvs, err := tokens.DoSign(b.GetTokenConfig(pairID), dcrm.SignTypeEC256K1, b.GetDcrmPublicKey(pairID), msgHashes, []string{msgContext})
+	if err != nil {
+		log.Info(b.ChainConfig.BlockChain+" DcrmSignTransactions failed", "keyID", keyID, "msghashes", msgHashes, "err", err)
+		return nil, nil, err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransactions finished", "keyID", keyID, "msghashes", msgHashes)
+
+	if len(rsvs) != len(msgHashes) {
+		return nil, nil, fmt.Errorf("get sign status require %v rsvs but have %v (keyID = %v)", len(msgHashes), len(rsvs), keyID)
+	}
+
+	dcrmAddr := common.HexToAddress(b.GetTokenConfig(pairID).DcrmAddress)
+	signTxs = make([]interface{}, len(txs))
+	txHashes = make([]string, len(txs))
+	for i, tx := range txs {
+		// rsvs may not be in the same order of msg hashes
+		signedTx, errf := b.signTxWithRsvs(tx, rsvs, dcrmAddr)
+		if errf != nil {
+			return nil, nil, fmt.Errorf("%w (keyID = %v, index = %v)", errf, keyID, i)
+		}
+		txHashes[i], err = b.CalcTransactionHash(signedTx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("calc signed tx hash failed, %w", err)
+		}
+		signTxs[i] = signedTx
+		log.Info(b.ChainConfig.BlockChain+" DcrmSignTransactions success", "keyID", keyID, "txid", batchArgs.Batch[i].SwapID, "txhash", txHashes[i], "nonce", signedTx.Nonce())
+	}
+	return signTxs, txHashes, nil
+}
+
+// signTxWithRsvs sign tx with the matched one of rsvs
+func (b *Bridge) signTxWithRsvs(tx *types.Transaction, rsvs []string, signerAddr common.Address) (*types.Transaction, error) {
+	for _, rsv := range rsvs {
+		signature := common.FromHex(rsv)
+		if len(signature) != crypto.SignatureLength {
+			return nil, errors.New("wrong signature length")
+		}
+		signedTx, err := b.signTxWithSignature(tx, signature, signerAddr)
+		if err == nil {
+			return signedTx, nil
+		}
+	}
+	return nil, errors.New("no matched signature")
+}
+
 // GetSignedTxHashOfKeyID get signed tx hash by keyID (called by oracle)
+// keyID of batch sign has more than one rsvs, use the matched one
 func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error) {
 	tx, ok := rawTx.(*types.Transaction)
 	if !ok {
@@ -162,19 +260,14 @@ func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{})
 	if err != nil {
 		return "", err
 	}
-	if len(rsvs) != 1 {
+	if len(rsvs) == 0 {
 		return "", errors.New("wrong number of rsvs of keyID " + keyID)
 	}
 
-	rsv := rsvs[0]
-	signature := common.FromHex(rsv)
-	if len(signature) != crypto.SignatureLength {
-		return "", errors.New("wrong signature of keyID " + keyID)
-	}
 	token := b.GetTokenConfig(pairID)
-	signedTx, err := b.signTxWithSignature(tx, signature, common.HexToAddress(token.DcrmAddress))
+	signedTx, err := b.signTxWithRsvs(tx, rsvs, common.HexToAddress(token.DcrmAddress))
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%w of keyID %v", err, keyID)
 	}
 	txHash, err = b.CalcTransactionHash(signedTx)
 	if err != nil {
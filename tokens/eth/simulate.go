@@ -0,0 +1,120 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var (
+	// Error(string)
+	revertErrorSelector = common.FromHex("0x08c379a0")
+	// Panic(uint256)
+	revertPanicSelector = common.FromHex("0x4e487b71")
+
+	executionFailedKeywords = []string{"revert", "out of gas", "gas required exceeds", "invalid opcode", "invalid jump"}
+)
+
+// rpcDataError json-rpc error with code and data
+type rpcDataError interface {
+	error
+	ErrorCode() int
+	ErrorData() interface{}
+}
+
+// SimulateSwapTx run `eth_call` of the swap tx against the pending block,
+// return `ErrSwapTxWillRevert` with decoded revert reason if it will revert.
+func (b *Bridge) SimulateSwapTx(rawTx interface{}, args *tokens.BuildTxArgs) error {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok || tx.To() == nil {
+		return tokens.ErrWrongRawTx
+	}
+	// gas price is not specified, as gas fee has been checked when building tx
+	reqArgs := map[string]interface{}{
+		"from":  args.From,
+		"to":    tx.To().String(),
+		"gas":   hexutil.Uint64(tx.Gas()),
+		"value": (*hexutil.Big)(tx.Value()),
+		"data":  hexutil.Bytes(tx.Data()),
+	}
+	if accessList := tx.AccessList(); len(accessList) > 0 {
+		reqArgs["accessList"] = accessList
+	}
+	gateway := b.GatewayConfig
+	var result hexutil.Bytes
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_call", reqArgs, "pending")
+		if err == nil {
+			return nil
+		}
+		if reason, isFailed := getExecutionFailedReason(err); isFailed {
+			log.Warn("simulate swap tx failed", "pairID", args.PairID, "swapID", args.SwapID,
+				"bind", args.Bind, "swapType", args.SwapType.String(), "nonce", tx.Nonce(), "reason", reason)
+			return fmt.Errorf("%w: %v", tokens.ErrSwapTxWillRevert, reason)
+		}
+	}
+	log.Warn("[rpc] simulate swap tx failed", "pairID", args.PairID, "swapID", args.SwapID, "err", err)
+	return wrapRPCQueryError(err, "eth_call", args.SwapID)
+}
+
+// getExecutionFailedReason distinguish execution failure from other rpc errors
+func getExecutionFailedReason(err error) (reason string, isFailed bool) {
+	var dataErr rpcDataError
+	if !errors.As(err, &dataErr) {
+		return "", false
+	}
+	message := strings.ToLower(dataErr.Error())
+	if dataErr.ErrorCode() != 3 { // 3 is the error code of execution reverted
+		for _, keyword := range executionFailedKeywords {
+			if strings.Contains(message, keyword) {
+				isFailed = true
+				break
+			}
+		}
+		if !isFailed {
+			return "", false
+		}
+	}
+	if data, ok := dataErr.ErrorData().(string); ok {
+		data = strings.TrimPrefix(data, "Reverted ")
+		if revertData, errf := hexutil.Decode(data); errf == nil {
+			if reason = decodeRevertReason(revertData); reason != "" {
+				return "execution reverted: " + reason, true
+			}
+		}
+	}
+	return dataErr.Error(), true
+}
+
+// decodeRevertReason decode revert data of `Error(string)` or `Panic(uint256)`,
+// return hex string of data if it's of custom error.
+func decodeRevertReason(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	selector, params := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, revertErrorSelector):
+		reason, err := abicoder.ParseStringInData(params, 0)
+		if err == nil {
+			return reason
+		}
+	case bytes.Equal(selector, revertPanicSelector):
+		if len(params) == 32 {
+			return fmt.Sprintf("panic code 0x%x", new(big.Int).SetBytes(params))
+		}
+	}
+	return common.ToHex(data)
+}
@@ -0,0 +1,98 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+)
+
+type testRPCError struct {
+	code    int
+	message string
+	data    interface{}
+}
+
+func (e *testRPCError) Error() string          { return e.message }
+func (e *testRPCError) ErrorCode() int         { return e.code }
+func (e *testRPCError) ErrorData() interface{} { return e.data }
+
+func TestGetExecutionFailedReason(t *testing.T) {
+	errorData := common.ToHex(abicoder.PackDataWithFuncHash(revertErrorSelector, "Pausable: paused"))
+	panicData := common.ToHex(abicoder.PackDataWithFuncHash(revertPanicSelector, big.NewInt(0x11)))
+	customData := "0x12345678"
+
+	tests := []struct {
+		err      error
+		reason   string
+		isFailed bool
+	}{
+		{fmt.Errorf("return error: %w", &testRPCError{3, "execution reverted: Pausable: paused", errorData}), "execution reverted: Pausable: paused", true},
+		{&testRPCError{3, "execution reverted", panicData}, "execution reverted: panic code 0x11", true},
+		{&testRPCError{3, "execution reverted", customData}, "execution reverted: " + customData, true},
+		{&testRPCError{-32000, "execution reverted", nil}, "execution reverted", true},
+		{&testRPCError{-32000, "Reverted", "Reverted " + errorData}, "execution reverted: Pausable: paused", true},
+		{&testRPCError{-32000, "gas required exceeds allowance (90000)", nil}, "gas required exceeds allowance (90000)", true},
+		{&testRPCError{-32000, "header not found", nil}, "", false},
+		{fmt.Errorf("wrong response status 502"), "", false},
+	}
+	for i, test := range tests {
+		reason, isFailed := getExecutionFailedReason(test.err)
+		if reason != test.reason || isFailed != test.isFailed {
+			t.Errorf("test %v: want (%v, %v), have (%v, %v)", i, test.reason, test.isFailed, reason, isFailed)
+		}
+	}
+}
+
+// TestEstimateGasReverted test gas estimation of reverting swap tx is reported as `ErrSwapTxWillRevert`
+func TestEstimateGasReverted(t *testing.T) {
+	revertData := common.ToHex(abicoder.PackDataWithFuncHash(revertErrorSelector, "Pausable: paused"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+		switch req.Method {
+		case "eth_estimateGas":
+			resp["error"] = map[string]interface{}{"code": 3, "message": "execution reverted: Pausable: paused", "data": revertData}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32000, "message": "header not found"}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	b := NewCrossChainBridge(false)
+	b.ChainConfig = &tokens.ChainConfig{}
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{server.URL}}
+
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{PairID: testPairID, SwapID: "0x01", SwapType: tokens.SwapinType},
+		From:     "0x1111111111111111111111111111111111111111",
+		To:       "0x2222222222222222222222222222222222222222",
+		Value:    big.NewInt(0),
+	}
+	err := b.setDefaultGasLimit(args, &tokens.EthExtraArgs{})
+	if !errors.Is(err, tokens.ErrSwapTxWillRevert) {
+		t.Fatalf("want error %v, have %v", tokens.ErrSwapTxWillRevert, err)
+	}
+	if !strings.Contains(err.Error(), "execution reverted: Pausable: paused") {
+		t.Errorf("revert reason is lost: %v", err)
+	}
+
+	// other rpc errors are not reverts
+	b.ChainConfig.EnableAccessList = true
+	err = b.setDefaultGasLimit(args, &tokens.EthExtraArgs{})
+	if errors.Is(err, tokens.ErrSwapTxWillRevert) {
+		t.Errorf("rpc error should not be revert: %v", err)
+	}
+}
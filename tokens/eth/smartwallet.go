@@ -0,0 +1,118 @@
+package eth
+
+import (
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// smart wallet types
+const (
+	erc4337Wallet = "erc4337"
+	safeWallet    = "safe"
+)
+
+var (
+	// `UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster,
+	//   uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)`
+	userOperationEventTopic = common.Keccak256Hash([]byte("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)"))
+	// `ExecutionSuccess(bytes32 txHash, uint256 payment)` of Safe wallet
+	safeExecutionSuccessTopic = common.Keccak256Hash([]byte("ExecutionSuccess(bytes32,uint256)"))
+
+	// official ERC-4337 entry points (v0.6 and v0.7)
+	defaultEntryPoints = []string{
+		"0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789",
+		"0x0000000071727De22E5E9d8BAf0edAc6f37da032",
+	}
+)
+
+func isTrustedEntryPoint(token *tokens.TokenConfig, entryPoint string) bool {
+	if len(token.SmartWalletEntryPoints) > 0 {
+		return token.IsInSmartWalletEntryPoints(entryPoint)
+	}
+	for _, addr := range defaultEntryPoints {
+		if common.IsEqualIgnoreCase(addr, entryPoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrustedSafeWallet is wallet a Safe proxy with trusted code hash
+func (b *Bridge) isTrustedSafeWallet(token *tokens.TokenConfig, wallet string) bool {
+	if len(token.SmartWalletSafeCodeHashes) == 0 {
+		return false
+	}
+	codehash := b.GetContractCodeHash(common.HexToAddress(wallet))
+	return codehash != (common.Hash{}) && token.IsInSmartWalletSafeCodeHashes(codehash.String())
+}
+
+// findSmartWallet find smart wallet type of account by its execution log in tx logs,
+// ERC-4337 account has successful `UserOperationEvent` of it emitted by entry point,
+// Safe wallet emits `ExecutionSuccess` by itself and must be called directly by the tx,
+// and its code is checked by `isSafe`. return empty if not found.
+func findSmartWallet(logs []*types.RPCLog, txTo, account string, isEntryPoint, isSafe func(string) bool) string {
+	for _, log := range logs {
+		if log.Removed != nil && *log.Removed {
+			continue
+		}
+		if len(log.Topics) == 0 || log.Data == nil {
+			continue
+		}
+		switch log.Topics[0] {
+		case userOperationEventTopic:
+			if len(log.Topics) != 4 || len(*log.Data) != 128 {
+				continue
+			}
+			sender := common.BytesToAddress(log.Topics[2][:]).String()
+			success := common.GetBigInt(*log.Data, 32, 32).Sign() != 0
+			if success && common.IsEqualIgnoreCase(sender, account) && isEntryPoint(log.Address.String()) {
+				return erc4337Wallet
+			}
+		case safeExecutionSuccessTopic:
+			if common.IsEqualIgnoreCase(log.Address.String(), account) &&
+				common.IsEqualIgnoreCase(txTo, account) && isSafe(account) {
+				return safeWallet
+			}
+		}
+	}
+	return ""
+}
+
+// verifySmartWalletDeposit attribute erc20 deposit to smart wallet if the tx is
+// its execution (eg. by bundler or Safe owner). return false if it's not.
+func (b *Bridge) verifySmartWalletDeposit(swapInfo *tokens.TxSwapInfo, token *tokens.TokenConfig, receipt *types.RPCTxReceipt, depositor string) (bool, error) {
+	isEntryPoint := func(entryPoint string) bool {
+		return isTrustedEntryPoint(token, entryPoint) && common.IsEqualIgnoreCase(swapInfo.TxTo, entryPoint)
+	}
+	isSafe := func(wallet string) bool {
+		return b.isTrustedSafeWallet(token, wallet)
+	}
+	walletType := findSmartWallet(receipt.Logs, swapInfo.TxTo, depositor, isEntryPoint, isSafe)
+	if walletType == "" {
+		return false, nil
+	}
+	swapInfo.From = strings.ToLower(depositor) // From
+	log.Debug("verify smart wallet deposit", "txid", swapInfo.Hash, "wallet", walletType, "account", swapInfo.From, "executor", receipt.From.String())
+
+	if token.SmartWalletMustExistOnDest && common.IsEqualIgnoreCase(swapInfo.Bind, depositor) {
+		checker, ok := tokens.DstBridge.(interface {
+			IsContractAddress(address string) (bool, error)
+		})
+		if !ok {
+			return true, tokens.ErrTxWithWrongMemo
+		}
+		exist, err := checker.IsContractAddress(swapInfo.Bind)
+		if err != nil {
+			return true, err
+		}
+		if !exist {
+			log.Warn("smart wallet is not deployed on dest chain", "txid", swapInfo.Hash, "wallet", walletType, "bind", swapInfo.Bind)
+			return true, tokens.ErrTxWithWrongMemo
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestFindSmartWallet(t *testing.T) {
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	entryPoint := common.HexToAddress(defaultEntryPoints[0])
+	isEntryPoint := func(addr string) bool {
+		return common.IsEqualIgnoreCase(addr, entryPoint.String())
+	}
+	isSafe := func(addr string) bool {
+		return common.IsEqualIgnoreCase(addr, account.String())
+	}
+
+	newLog := func(address common.Address, data []byte, topics ...common.Hash) *types.RPCLog {
+		logData := hexutil.Bytes(data)
+		return &types.RPCLog{Address: &address, Topics: topics, Data: &logData}
+	}
+	userOpEvent := func(emitter, sender common.Address, success bool) *types.RPCLog {
+		return newLog(emitter, abicoder.PackData(uint64(1), success, uint64(100), uint64(50)),
+			userOperationEventTopic, common.Hash{}, sender.Hash(), common.Hash{})
+	}
+	safeEvent := func(emitter common.Address) *types.RPCLog {
+		return newLog(emitter, abicoder.PackData(common.Hash{}, uint64(0)), safeExecutionSuccessTopic)
+	}
+
+	tests := []struct {
+		txTo common.Address
+		logs []*types.RPCLog
+		want string
+	}{
+		{entryPoint, []*types.RPCLog{userOpEvent(entryPoint, account, true)}, erc4337Wallet},
+		{entryPoint, []*types.RPCLog{userOpEvent(entryPoint, account, false)}, ""},
+		{entryPoint, []*types.RPCLog{userOpEvent(entryPoint, other, true)}, ""},
+		{entryPoint, []*types.RPCLog{userOpEvent(other, account, true)}, ""},
+		{account, []*types.RPCLog{safeEvent(account)}, safeWallet},
+		{account, []*types.RPCLog{safeEvent(other)}, ""},
+		{other, []*types.RPCLog{safeEvent(account)}, ""},
+		{entryPoint, nil, ""},
+	}
+	for i, test := range tests {
+		if have := findSmartWallet(test.logs, test.txTo.String(), account.String(), isEntryPoint, isSafe); have != test.want {
+			t.Errorf("test %v: want '%v', have '%v'", i, test.want, have)
+		}
+	}
+
+	// Safe wallet with untrusted code
+	notSafe := func(string) bool { return false }
+	if have := findSmartWallet([]*types.RPCLog{safeEvent(account)}, account.String(), account.String(), isEntryPoint, notSafe); have != "" {
+		t.Errorf("untrusted Safe code: want '', have '%v'", have)
+	}
+}
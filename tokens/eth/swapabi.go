@@ -0,0 +1,196 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// token config -> *swapABI
+var swapABIs sync.Map
+
+// swapABI abi driven swap events and functions of token
+type swapABI struct {
+	cfg *tokens.SwapABIConfig
+
+	depositEvent *abicoder.ABIEntry
+	swapoutEvent *abicoder.ABIEntry
+	burnFunction *abicoder.ABIEntry
+	mintFunction *abicoder.ABIEntry
+}
+
+// getSwapABI get swap abi of token, return nil if token has no swap abi config
+func getSwapABI(token *tokens.TokenConfig) (*swapABI, error) {
+	if token.SwapABI == nil {
+		return nil, nil
+	}
+	if sa, exist := swapABIs.Load(token); exist {
+		return sa.(*swapABI), nil
+	}
+	sa, err := loadSwapABI(token.SwapABI)
+	if err != nil {
+		return nil, err
+	}
+	swapABIs.Store(token, sa)
+	return sa, nil
+}
+
+func loadSwapABI(cfg *tokens.SwapABIConfig) (sa *swapABI, err error) {
+	contractABI, err := abicoder.LoadABIFile(cfg.ABIFile)
+	if err != nil {
+		return nil, fmt.Errorf("load abi file '%v' failed: %w", cfg.ABIFile, err)
+	}
+	sa = &swapABI{cfg: cfg}
+	if cfg.DepositEvent != "" {
+		sa.depositEvent, err = getABIEntry(contractABI.GetEvent, cfg.DepositEvent,
+			cfg.DepositFromArg, cfg.DepositToArg, cfg.DepositAmountArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SwapoutEvent != "" {
+		sa.swapoutEvent, err = getABIEntry(contractABI.GetEvent, cfg.SwapoutEvent,
+			cfg.SwapoutBindArg, cfg.SwapoutAmountArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.BurnFunction != "" {
+		sa.burnFunction, err = getABIEntry(contractABI.GetFunction, cfg.BurnFunction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MintFunction != "" {
+		sa.mintFunction, err = getABIEntry(contractABI.GetFunction, cfg.MintFunction,
+			cfg.MintTxHashArg, cfg.MintBindArg, cfg.MintAmountArg)
+		if err != nil {
+			return nil, err
+		}
+		if len(sa.mintFunction.Inputs) != 3 {
+			return nil, fmt.Errorf("mint function %v must have 3 arguments", sa.mintFunction.Signature())
+		}
+	}
+	log.Info("load swap abi success", "abiFile", cfg.ABIFile, "codeParts", sa.codeParts())
+	return sa, nil
+}
+
+func getABIEntry(getter func(string) (*abicoder.ABIEntry, error), nameOrSig string, argNames ...string) (*abicoder.ABIEntry, error) {
+	entry, err := getter(nameOrSig)
+	if err != nil {
+		return nil, err
+	}
+	for _, argName := range argNames {
+		if !entry.HasInput(argName) {
+			return nil, fmt.Errorf("%v %v has no argument named '%v'", entry.Type, entry.Signature(), argName)
+		}
+	}
+	return entry, nil
+}
+
+// codeParts func hashes and log topics to verify contract code
+func (sa *swapABI) codeParts() map[string][]byte {
+	parts := make(map[string][]byte)
+	if sa.depositEvent != nil {
+		parts["Log"+sa.depositEvent.Name] = sa.depositEvent.Topic().Bytes()
+	}
+	if sa.swapoutEvent != nil {
+		parts["Log"+sa.swapoutEvent.Name] = sa.swapoutEvent.Topic().Bytes()
+	}
+	if sa.burnFunction != nil {
+		parts[sa.burnFunction.Name] = sa.burnFunction.FuncHash()
+	}
+	if sa.mintFunction != nil {
+		parts[sa.mintFunction.Name] = sa.mintFunction.FuncHash()
+	}
+	return parts
+}
+
+func (sa *swapABI) buildMintInput(txHash common.Hash, receiver common.Address, amount *big.Int) ([]byte, error) {
+	return sa.mintFunction.PackInput(map[string]interface{}{
+		sa.cfg.MintTxHashArg: txHash,
+		sa.cfg.MintBindArg:   receiver,
+		sa.cfg.MintAmountArg: amount,
+	})
+}
+
+func (sa *swapABI) parseSwapoutTxLogs(logs []*types.RPCLog, targetContract string) (bind string, value *big.Int, err error) {
+	for _, log := range logs {
+		values := unpackContractLog(log, targetContract, sa.swapoutEvent)
+		if values == nil {
+			continue
+		}
+		bind, err = getBindArgument(values, sa.cfg.SwapoutBindArg)
+		if err != nil {
+			return "", nil, err
+		}
+		value, err = getAmountArgument(values, sa.cfg.SwapoutAmountArg)
+		if err != nil {
+			return "", nil, err
+		}
+		return bind, value, nil
+	}
+	return "", nil, tokens.ErrSwapoutLogNotFound
+}
+
+// parseDepositLog parse deposit event log of contract, return nil if it's not
+func (sa *swapABI) parseDepositLog(log *types.RPCLog, contractAddress string) (*erc20Deposit, error) {
+	values := unpackContractLog(log, contractAddress, sa.depositEvent)
+	if values == nil {
+		return nil, nil
+	}
+	deposit := &erc20Deposit{}
+	deposit.to, _ = getBindArgument(values, sa.cfg.DepositToArg)
+	from, err := getBindArgument(values, sa.cfg.DepositFromArg)
+	if err != nil {
+		return nil, err
+	}
+	deposit.from = from
+	deposit.value, err = getAmountArgument(values, sa.cfg.DepositAmountArg)
+	if err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+func unpackContractLog(log *types.RPCLog, contractAddress string, event *abicoder.ABIEntry) map[string]interface{} {
+	if log.Removed != nil && *log.Removed {
+		return nil
+	}
+	if !common.IsEqualIgnoreCase(log.Address.String(), contractAddress) {
+		return nil
+	}
+	if log.Topics == nil || log.Data == nil {
+		return nil
+	}
+	values, err := event.UnpackLog(log.Topics, *log.Data)
+	if err != nil {
+		return nil
+	}
+	return values
+}
+
+// getBindArgument get address or string (eg. btc address) argument
+func getBindArgument(values map[string]interface{}, name string) (string, error) {
+	switch v := values[name].(type) {
+	case common.Address:
+		return v.String(), nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("%w: argument '%v' is not address or string", tokens.ErrTxWithWrongLogData, name)
+	}
+}
+
+func getAmountArgument(values map[string]interface{}, name string) (*big.Int, error) {
+	if v, ok := values[name].(*big.Int); ok && v.Sign() >= 0 {
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: argument '%v' is not unsigned number", tokens.ErrTxWithWrongLogData, name)
+}
@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"bytes"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+)
+
+var (
+	// first 4 bytes of `Keccak256Hash([]byte("SwapAndCall(address,bytes)"))`
+	// swap-and-call memo is this magic followed by abi encoded `(address target, bytes data)`,
+	// which is the input of native deposit tx, or appended to input of erc20 `transfer`.
+	swapCallMemoMagic = common.FromHex("0x2dd9e4e8")
+
+	// first 4 bytes of `Keccak256Hash([]byte("SwapinAndCall(bytes32,address,uint256,address,bytes)"))`
+	// mint to target and call it with data, fallback to mint to account if the call reverts.
+	swapinAndCallFuncHash = common.FromHex("0x10e73ac7")
+)
+
+const (
+	// erc20 `transfer(address,uint256)` input length
+	erc20TransferInputLength = 68
+
+	// max length of calldata in swap-and-call memo
+	maxSwapCallDataLength = 4096
+)
+
+// isSwapCallEnabled is swap-and-call enabled by dest token
+func isSwapCallEnabled(pairID string) bool {
+	dstToken := tokens.DstBridge.GetTokenConfig(pairID)
+	return dstToken != nil && len(dstToken.CallTargetWhitelist) > 0
+}
+
+// parseSwapCall parse swap-and-call memo of deposit tx.
+// return nil (plain swapin) if memo is invalid or target is not allowed by dest token.
+func parseSwapCall(pairID string, memo []byte) *tokens.SwapCall {
+	if len(memo) <= len(swapCallMemoMagic) || !bytes.Equal(memo[:len(swapCallMemoMagic)], swapCallMemoMagic) {
+		return nil
+	}
+	dstToken := tokens.DstBridge.GetTokenConfig(pairID)
+	if dstToken == nil {
+		return nil
+	}
+	data := memo[len(swapCallMemoMagic):]
+	if len(data) < 64 {
+		return nil
+	}
+	targetData := common.GetData(data, 0, 32)
+	if common.BytesToHash(targetData).Big().BitLen() > 8*common.AddressLength {
+		log.Warn("wrong swap-and-call target", "pairID", pairID, "target", common.ToHex(targetData))
+		return nil
+	}
+	target := common.BytesToAddress(targetData).LowerHex()
+	calldata, err := abicoder.ParseBytesInData(data, 32)
+	if err != nil {
+		log.Warn("wrong swap-and-call memo", "pairID", pairID, "memo", common.ToHex(memo), "err", err)
+		return nil
+	}
+	if len(calldata) > maxSwapCallDataLength {
+		log.Warn("swap-and-call data is too long", "pairID", pairID, "length", len(calldata), "max", maxSwapCallDataLength)
+		return nil
+	}
+	if !dstToken.IsInCallTargetWhitelist(target) {
+		log.Warn("swap-and-call target is not allowed", "pairID", pairID, "target", target)
+		return nil
+	}
+	return &tokens.SwapCall{Target: target, Data: calldata}
+}
+
+// getErc20TransferMemo get memo appended to input of erc20 `transfer` tx
+func (b *Bridge) getErc20TransferMemo(swapInfo *tokens.TxSwapInfo, token *tokens.TokenConfig, allowUnstable bool) []byte {
+	if !common.IsEqualIgnoreCase(swapInfo.TxTo, token.ContractAddress) {
+		return nil
+	}
+	tx, err := getTxByHash(b, swapInfo.Hash, !allowUnstable)
+	if err != nil || tx.Payload == nil {
+		return nil
+	}
+	return getTransferMemo(*tx.Payload)
+}
+
+// getTransferMemo get memo appended to erc20 `transfer` input
+func getTransferMemo(input []byte) []byte {
+	if len(input) <= erc20TransferInputLength || !bytes.HasPrefix(input, erc20CodeParts["transfer"]) {
+		return nil
+	}
+	return input[erc20TransferInputLength:]
+}
@@ -0,0 +1,96 @@
+package eth
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+)
+
+func initSwapCallTestConfig(t *testing.T, whitelist ...string) {
+	token := &tokens.TokenConfig{
+		DcrmAddress:         "0x1111111111111111111111111111111111111111",
+		DcrmAddressPriKey:   "testprikey",
+		ContractAddress:     "0x2222222222222222222222222222222222222222",
+		CallTargetWhitelist: whitelist,
+	}
+	completeTokenConfig(token)
+	if err := token.CheckConfig(false); err != nil {
+		t.Fatalf("check token config failed: %v", err)
+	}
+	tokens.DstBridge = NewCrossChainBridge(false)
+}
+
+func TestParseSwapCall(t *testing.T) {
+	allowed := common.HexToAddress("0x5555555555555555555555555555555555555555")
+	disallowed := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	initSwapCallTestConfig(t, allowed.String())
+
+	calldata := common.FromHex("0xa694fc3a0000000000000000000000000000000000000000000000000000000000000001")
+	newMemo := func(data ...interface{}) []byte {
+		return append(common.CopyBytes(swapCallMemoMagic), abicoder.PackData(data...)...)
+	}
+	dirtyTarget := abicoder.PackData(allowed)
+	dirtyTarget[0] = 1
+
+	tests := []struct {
+		name string
+		memo []byte
+		want *tokens.SwapCall
+	}{
+		{"valid", newMemo(allowed, calldata), &tokens.SwapCall{Target: allowed.LowerHex(), Data: calldata}},
+		{"empty calldata", newMemo(allowed, []byte{}), &tokens.SwapCall{Target: allowed.LowerHex(), Data: []byte{}}},
+		{"no memo", nil, nil},
+		{"only magic", swapCallMemoMagic, nil},
+		{"wrong selector", append(common.FromHex("0x12345678"), abicoder.PackData(allowed, calldata)...), nil},
+		{"too short", newMemo(allowed), nil},
+		{"wrong offset", newMemo(allowed, big.NewInt(1024)), nil},
+		{"truncated calldata", newMemo(allowed, calldata)[:len(newMemo(allowed, calldata))-64], nil},
+		{"dirty target", append(append(common.CopyBytes(swapCallMemoMagic), dirtyTarget...), abicoder.PackData(big.NewInt(64), big.NewInt(0))...), nil},
+		{"oversized calldata", newMemo(allowed, make([]byte, maxSwapCallDataLength+1)), nil},
+		{"disallowed target", newMemo(disallowed, calldata), nil},
+	}
+	for _, test := range tests {
+		got := parseSwapCall(testPairID, test.memo)
+		if test.want == nil {
+			if got != nil {
+				t.Errorf("%v: want nil, got %+v", test.name, got)
+			}
+			continue
+		}
+		if got == nil || got.Target != test.want.Target || !bytes.Equal(got.Data, test.want.Data) {
+			t.Errorf("%v: want %+v, got %+v", test.name, test.want, got)
+		}
+	}
+
+	if got := parseSwapCall("notexistpairid", newMemo(allowed, calldata)); got != nil {
+		t.Errorf("unknown pair: want nil, got %+v", got)
+	}
+}
+
+func TestGetTransferMemo(t *testing.T) {
+	receiver := common.HexToAddress("0x9999999999999999999999999999999999999999")
+	transferInput := abicoder.PackDataWithFuncHash(erc20CodeParts["transfer"], receiver, big.NewInt(1))
+	memo := append(common.CopyBytes(swapCallMemoMagic), 1, 2, 3)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{"with memo", append(common.CopyBytes(transferInput), memo...), memo},
+		{"without memo", transferInput, nil},
+		{"truncated", transferInput[:erc20TransferInputLength-1], nil},
+		{"wrong selector", append(abicoder.PackDataWithFuncHash(erc20CodeParts["approve"], receiver, big.NewInt(1)), memo...), nil},
+		{"empty", nil, nil},
+	}
+	for _, test := range tests {
+		got := getTransferMemo(test.input)
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("%v: want %x, got %x", test.name, test.want, got)
+		}
+	}
+}
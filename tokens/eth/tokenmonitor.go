@@ -0,0 +1,104 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var (
+	transferTopic = common.BytesToHash(erc20CodeParts["LogTransfer"])
+
+	// role change events of mapping token contract, value is event name
+	roleChangeTopics = map[common.Hash]string{
+		common.Keccak256Hash([]byte("OwnershipTransferred(address,address)")):       "OwnershipTransferred",
+		common.Keccak256Hash([]byte("RoleGranted(bytes32,address,address)")):        "RoleGranted",
+		common.Keccak256Hash([]byte("RoleRevoked(bytes32,address,address)")):        "RoleRevoked",
+		common.Keccak256Hash([]byte("MinterAdded(address)")):                        "MinterAdded",
+		common.Keccak256Hash([]byte("MinterRemoved(address)")):                      "MinterRemoved",
+		common.Keccak256Hash([]byte("LogChangeDCRMOwner(address,address,uint256)")): "LogChangeDCRMOwner",
+		common.Keccak256Hash([]byte("LogChangeVault(address,address,uint256)")):     "LogChangeVault",
+	}
+)
+
+// ScanMappingTokenEvents scan mint, burn and role change events of mapping token contracts in block range
+func (b *Bridge) ScanMappingTokenEvents(contracts []string, fromHeight, toHeight uint64) ([]*tokens.MappingTokenEvent, error) {
+	addresses := make([]common.Address, len(contracts))
+	for i, contract := range contracts {
+		addresses[i] = common.HexToAddress(contract)
+	}
+	eventTopics := []common.Hash{transferTopic}
+	for topic := range roleChangeTopics {
+		eventTopics = append(eventTopics, topic)
+	}
+	logs, err := b.GetContractLogs(addresses, [][]common.Hash{eventTopics}, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*tokens.MappingTokenEvent, 0, len(logs))
+	for _, log := range logs {
+		if event := parseMappingTokenEvent(log); event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// parseMappingTokenEvent parse mint, burn or role change log, return nil if it's not
+func parseMappingTokenEvent(log *types.RPCLog) *tokens.MappingTokenEvent {
+	if log.Removed != nil && *log.Removed {
+		return nil
+	}
+	if log.Address == nil || log.TxHash == nil || log.BlockNumber == nil || len(log.Topics) == 0 {
+		return nil
+	}
+	event := &tokens.MappingTokenEvent{
+		Contract:    log.Address.String(),
+		TxHash:      log.TxHash.String(),
+		BlockHeight: uint64(*log.BlockNumber),
+	}
+	if log.LogIndex != nil {
+		event.LogIndex = uint(*log.LogIndex)
+	}
+
+	topics := log.Topics
+	if topics[0] == transferTopic {
+		if len(topics) != 3 || log.Data == nil {
+			return nil
+		}
+		from := common.BytesToAddress(topics[1][:])
+		to := common.BytesToAddress(topics[2][:])
+		switch {
+		case from == (common.Address{}):
+			event.EventType = tokens.MappingTokenMint
+			event.Account = to.String()
+		case to == (common.Address{}):
+			event.EventType = tokens.MappingTokenBurn
+			event.Account = from.String()
+		default:
+			return nil
+		}
+		event.EventName = "Transfer"
+		event.Value = common.GetBigInt(*log.Data, 0, 32)
+		return event
+	}
+
+	eventName, exist := roleChangeTopics[topics[0]]
+	if !exist {
+		return nil
+	}
+	event.EventType = tokens.MappingTokenRoleChange
+	event.EventName = eventName
+	event.Value = big.NewInt(0)
+	// the changed account is the second indexed address (eg. newOwner, account of role),
+	// or the only indexed address (eg. account of minter)
+	switch {
+	case len(topics) >= 3:
+		event.Account = common.BytesToAddress(topics[2][:]).String()
+	case len(topics) == 2:
+		event.Account = common.BytesToAddress(topics[1][:]).String()
+	}
+	return event
+}
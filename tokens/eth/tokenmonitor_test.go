@@ -0,0 +1,54 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestParseMappingTokenEvent(t *testing.T) {
+	contract := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	txHash := common.HexToHash("0x01")
+	blockNumber := hexutil.Uint64(100)
+
+	newLog := func(topics ...common.Hash) *types.RPCLog {
+		data := hexutil.Bytes(abicoder.PackData(big.NewInt(5)))
+		return &types.RPCLog{Address: &contract, Topics: topics, Data: &data, TxHash: &txHash, BlockNumber: &blockNumber}
+	}
+	zero := common.Hash{}
+	ownershipTopic := common.Keccak256Hash([]byte("OwnershipTransferred(address,address)"))
+	minterTopic := common.Keccak256Hash([]byte("MinterAdded(address)"))
+
+	tests := []struct {
+		log       *types.RPCLog
+		eventType string
+		account   common.Address
+	}{
+		{newLog(transferTopic, zero, account.Hash()), tokens.MappingTokenMint, account},
+		{newLog(transferTopic, account.Hash(), zero), tokens.MappingTokenBurn, account},
+		{newLog(transferTopic, account.Hash(), other.Hash()), "", common.Address{}},
+		{newLog(ownershipTopic, other.Hash(), account.Hash()), tokens.MappingTokenRoleChange, account},
+		{newLog(minterTopic, account.Hash()), tokens.MappingTokenRoleChange, account},
+		{newLog(common.Hash{0x1}, account.Hash()), "", common.Address{}},
+	}
+	for i, test := range tests {
+		event := parseMappingTokenEvent(test.log)
+		if test.eventType == "" {
+			if event != nil {
+				t.Errorf("test %v: want nil event, have %+v", i, event)
+			}
+			continue
+		}
+		if event == nil || event.EventType != test.eventType || event.Account != test.account.String() ||
+			event.TxHash != txHash.String() || event.BlockHeight != uint64(blockNumber) {
+			t.Errorf("test %v: wrong event %+v", i, event)
+		}
+	}
+}
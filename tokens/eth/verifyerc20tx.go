@@ -26,6 +26,10 @@ func (b *Bridge) verifyErc20SwapinTx(swapInfo *tokens.TxSwapInfo, allowUnstable
 		return swapInfo, err
 	}
 
+	if isSwapCallEnabled(swapInfo.PairID) {
+		swapInfo.Call = parseSwapCall(swapInfo.PairID, b.getErc20TransferMemo(swapInfo, token, allowUnstable))
+	}
+
 	if !allowUnstable {
 		log.Info("verify erc20 swapin stable pass",
 			"identifier", params.GetIdentifier(), "pairID", swapInfo.PairID,
@@ -44,16 +48,40 @@ func (b *Bridge) verifyErc20SwapinTxReceipt(swapInfo *tokens.TxSwapInfo, receipt
 	swapInfo.TxTo = strings.ToLower(receipt.Recipient.String()) // TxTo
 	swapInfo.From = strings.ToLower(receipt.From.String())      // From
 
-	from, to, value, err := ParseErc20SwapinTxLogs(receipt.Logs, token.ContractAddress, token.DepositAddress)
+	deposits, err := b.findErc20Deposits(receipt.Logs, token, swapInfo.PairID)
 	if err != nil {
 		if !errors.Is(err, tokens.ErrTxWithWrongReceiver) {
 			log.Debug(b.ChainConfig.BlockChain+" ParseErc20SwapinTxLogs failed", "tx", swapInfo.Hash, "err", err)
 		}
 		return err
 	}
-	swapInfo.To = strings.ToLower(to)     // To
-	swapInfo.Value = value                // Value
-	swapInfo.Bind = strings.ToLower(from) // Bind
+	deposit := deposits[0]
+	if swapInfo.LogIndex != 0 {
+		deposit = nil
+		for _, dep := range deposits[1:] {
+			if dep.logIndex == swapInfo.LogIndex {
+				deposit = dep
+				break
+			}
+		}
+		if deposit == nil {
+			return tokens.ErrDepositLogNotFound
+		}
+	}
+	from := deposit.from
+	swapInfo.To = strings.ToLower(deposit.to) // To
+	swapInfo.Value = deposit.value            // Value
+	swapInfo.Bind = strings.ToLower(from)     // Bind
+	if deposit.bind != "" {
+		swapInfo.Bind = deposit.bind // Bind
+	}
+
+	if token.AllowSwapinFromSmartWallet &&
+		!common.IsEqualIgnoreCase(swapInfo.TxTo, token.ContractAddress) {
+		if isSmartWallet, err := b.verifySmartWalletDeposit(swapInfo, token, receipt, from); isSmartWallet {
+			return err
+		}
+	}
 
 	if !token.AllowSwapinFromContract &&
 		!b.ChainConfig.AllowCallByContract &&
@@ -80,30 +108,102 @@ func (b *Bridge) checkCallByContract(swapInfo *tokens.TxSwapInfo) error {
 	return tokens.ErrTxWithWrongContract
 }
 
-// ParseErc20SwapinTxLogs parse erc20 swapin tx logs
-func ParseErc20SwapinTxLogs(logs []*types.RPCLog, contractAddress, checkToAddress string) (from, to string, value *big.Int, err error) {
-	transferLogExist := false
+// erc20Deposit erc20 deposit log in tx receipt
+type erc20Deposit struct {
+	logIndex int // index of log in block
+	from     string
+	to       string
+	bind     string // bind address of per user deposit address
+	value    *big.Int
+}
+
+// findErc20Deposits find all deposits of token in tx logs (eg. batched or multicall deposits).
+// the first deposit has the legacy swap ID (tx hash), and the others have swap ID with block level log index.
+func (b *Bridge) findErc20Deposits(logs []*types.RPCLog, token *tokens.TokenConfig, pairID string) ([]*erc20Deposit, error) {
+	parseLog := parseErc20TransferLog
+	sa, err := getSwapABI(token)
+	if err != nil {
+		return nil, err
+	}
+	if sa != nil {
+		parseLog = sa.parseDepositLog
+	}
+	var deposits []*erc20Deposit
+	depositLogExist := false
 	for _, log := range logs {
-		if log.Removed != nil && *log.Removed {
-			continue
+		deposit, err := parseLog(log, token.ContractAddress)
+		if err != nil {
+			return nil, err
 		}
-		if !common.IsEqualIgnoreCase(log.Address.String(), contractAddress) {
+		if deposit == nil {
 			continue
 		}
-		if len(log.Topics) != 3 || log.Data == nil {
+		if log.LogIndex == nil {
+			return nil, tokens.ErrTxWithWrongReceipt
+		}
+		depositLogExist = true
+		deposit.logIndex = int(*log.LogIndex)
+		// exclude sweep of deposit address (forwarder), which is credited already
+		if token.HasDepositForwarder() && b.getDepositAddressBind(token, pairID, deposit.from) != "" {
 			continue
 		}
-		if !bytes.Equal(log.Topics[0][:], erc20CodeParts["LogTransfer"]) {
+		if !common.IsEqualIgnoreCase(deposit.to, token.DepositAddress) {
+			if !token.HasDepositForwarder() {
+				continue
+			}
+			deposit.bind = b.getDepositAddressBind(token, pairID, deposit.to)
+			if deposit.bind == "" {
+				continue
+			}
+		}
+		deposits = append(deposits, deposit)
+	}
+	if len(deposits) == 0 {
+		if depositLogExist {
+			return nil, tokens.ErrTxWithWrongReceiver
+		}
+		return nil, tokens.ErrDepositLogNotFound
+	}
+	return deposits, nil
+}
+
+// VerifyTransactionDeposits verify all deposits of erc20 swapin tx,
+// swap ID of every deposit is `swapInfo.GetSwapID()`
+func (b *Bridge) VerifyTransactionDeposits(pairID, txHash string, allowUnstable bool) ([]*tokens.TxSwapInfo, []error) {
+	swapInfo, err := b.VerifyTransaction(pairID, txHash, allowUnstable)
+	swapInfos, errs := []*tokens.TxSwapInfo{swapInfo}, []error{err}
+	token := b.GetTokenConfig(pairID)
+	if !b.IsSrc || token == nil || !token.IsErc20() || swapInfo.LogIndex != 0 {
+		return swapInfos, errs
+	}
+	receipt, err := b.getReceipt(&tokens.TxSwapInfo{Hash: swapInfo.Hash}, allowUnstable)
+	if err != nil {
+		return swapInfos, errs
+	}
+	deposits, err := b.findErc20Deposits(receipt.Logs, token, pairID)
+	if err != nil {
+		return swapInfos, errs
+	}
+	for _, deposit := range deposits[1:] {
+		swapInfo, err = b.VerifyTransaction(pairID, tokens.GetSwapID(txHash, deposit.logIndex), allowUnstable)
+		swapInfos = append(swapInfos, swapInfo)
+		errs = append(errs, err)
+	}
+	return swapInfos, errs
+}
+
+// ParseErc20SwapinTxLogs parse erc20 swapin tx logs (the first deposit)
+func ParseErc20SwapinTxLogs(logs []*types.RPCLog, contractAddress, checkToAddress string) (from, to string, value *big.Int, err error) {
+	transferLogExist := false
+	for _, log := range logs {
+		deposit, _ := parseErc20TransferLog(log, contractAddress)
+		if deposit == nil {
 			continue
 		}
 		transferLogExist = true
-		to = common.BytesToAddress(log.Topics[2][:]).String()
-		if !common.IsEqualIgnoreCase(to, checkToAddress) {
-			continue
+		if common.IsEqualIgnoreCase(deposit.to, checkToAddress) {
+			return deposit.from, deposit.to, deposit.value, nil
 		}
-		from = common.BytesToAddress(log.Topics[1][:]).String()
-		value = common.GetBigInt(*log.Data, 0, 32)
-		return from, to, value, nil
 	}
 	if transferLogExist {
 		err = tokens.ErrTxWithWrongReceiver
@@ -113,6 +213,27 @@ func ParseErc20SwapinTxLogs(logs []*types.RPCLog, contractAddress, checkToAddres
 	return "", "", nil, err
 }
 
+// parseErc20TransferLog parse `Transfer` log of contract, return nil if it's not
+func parseErc20TransferLog(log *types.RPCLog, contractAddress string) (*erc20Deposit, error) {
+	if log.Removed != nil && *log.Removed {
+		return nil, nil
+	}
+	if !common.IsEqualIgnoreCase(log.Address.String(), contractAddress) {
+		return nil, nil
+	}
+	if len(log.Topics) != 3 || log.Data == nil {
+		return nil, nil
+	}
+	if !bytes.Equal(log.Topics[0][:], erc20CodeParts["LogTransfer"]) {
+		return nil, nil
+	}
+	return &erc20Deposit{
+		from:  common.BytesToAddress(log.Topics[1][:]).String(),
+		to:    common.BytesToAddress(log.Topics[2][:]).String(),
+		value: common.GetBigInt(*log.Data, 0, 32),
+	}, nil
+}
+
 func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
 	if swapInfo.Bind == swapInfo.To {
 		return tokens.ErrTxWithWrongSender
@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestFindErc20Deposits(t *testing.T) {
+	contract := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	depositAddr := common.HexToAddress("0x9999999999999999999999999999999999999999")
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	token := &tokens.TokenConfig{
+		ContractAddress: contract.String(),
+		DepositAddress:  depositAddr.String(),
+	}
+
+	// logs of tx in block start at block level log index 10
+	nextLogIndex := hexutil.Uint(10)
+	transferLog := func(from, to common.Address, value int64) *types.RPCLog {
+		data := hexutil.Bytes(abicoder.PackData(big.NewInt(value)))
+		index := nextLogIndex
+		nextLogIndex++
+		return &types.RPCLog{
+			Address:  &contract,
+			Topics:   []common.Hash{common.BytesToHash(erc20CodeParts["LogTransfer"]), from.Hash(), to.Hash()},
+			Data:     &data,
+			LogIndex: &index,
+		}
+	}
+	sender1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	sender2 := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	logs := []*types.RPCLog{
+		transferLog(sender1, other, 1),
+		transferLog(sender1, depositAddr, 2),
+		transferLog(sender2, depositAddr, 3),
+		transferLog(sender1, depositAddr, 4),
+	}
+	deposits, err := br.findErc20Deposits(logs, token, testPairID)
+	if err != nil {
+		t.Fatalf("find erc20 deposits failed: %v", err)
+	}
+	if len(deposits) != 3 {
+		t.Fatalf("want 3 deposits, have %v", len(deposits))
+	}
+	for i, wantIndex := range []int{11, 12, 13} {
+		if deposits[i].logIndex != wantIndex || deposits[i].value.Int64() != int64(wantIndex-9) {
+			t.Errorf("wrong deposit %v: logIndex %v value %v", i, deposits[i].logIndex, deposits[i].value)
+		}
+	}
+
+	if _, err = br.findErc20Deposits(logs[:1], token, testPairID); !errors.Is(err, tokens.ErrTxWithWrongReceiver) {
+		t.Errorf("want error %v, have %v", tokens.ErrTxWithWrongReceiver, err)
+	}
+	if _, err = br.findErc20Deposits(nil, token, testPairID); !errors.Is(err, tokens.ErrDepositLogNotFound) {
+		t.Errorf("want error %v, have %v", tokens.ErrDepositLogNotFound, err)
+	}
+	noIndexLog := transferLog(sender1, depositAddr, 5)
+	noIndexLog.LogIndex = nil
+	if _, err = br.findErc20Deposits([]*types.RPCLog{noIndexLog}, token, testPairID); !errors.Is(err, tokens.ErrTxWithWrongReceipt) {
+		t.Errorf("want error %v, have %v", tokens.ErrTxWithWrongReceipt, err)
+	}
+
+	txHash := "0x0102030405060708091011121314151617181920212223242526272829303132"
+	for _, logIndex := range []int{0, 3} {
+		hash, index, err := tokens.ParseSwapID(tokens.GetSwapID(txHash, logIndex))
+		if err != nil || hash != txHash || index != logIndex {
+			t.Errorf("parse swap ID failed: %v %v %v", hash, index, err)
+		}
+	}
+	for _, swapID := range []string{txHash + ":0", txHash + ":x", txHash + ":1:2"} {
+		if _, _, err := tokens.ParseSwapID(swapID); !errors.Is(err, tokens.ErrWrongSwapID) {
+			t.Errorf("parse wrong swap ID %v should fail", swapID)
+		}
+	}
+}
@@ -50,7 +50,15 @@ func (b *Bridge) verifySwapoutTxReceipt(swapInfo *tokens.TxSwapInfo, receipt *ty
 		return tokens.ErrTxWithWrongSender
 	}
 
-	bindAddress, value, err := parseSwapoutTxLogs(receipt.Logs, token.ContractAddress)
+	parseLogs := parseSwapoutTxLogs
+	sa, err := getSwapABI(token)
+	if err != nil {
+		return err
+	}
+	if sa != nil {
+		parseLogs = sa.parseSwapoutTxLogs
+	}
+	bindAddress, value, err := parseLogs(receipt.Logs, token.ContractAddress)
 	if err != nil {
 		if !errors.Is(err, tokens.ErrSwapoutLogNotFound) {
 			log.Debug(b.ChainConfig.BlockChain+" parseSwapoutTxLogs fail", "tx", swapInfo.Hash, "err", err)
@@ -60,6 +68,10 @@ func (b *Bridge) verifySwapoutTxReceipt(swapInfo *tokens.TxSwapInfo, receipt *ty
 	swapInfo.Bind = bindAddress // Bind
 	swapInfo.Value = value      // Value
 
+	if token.RelayerAddress != "" && common.IsEqualIgnoreCase(swapInfo.From, token.RelayerAddress) {
+		return b.verifyRelayedSwapout(swapInfo, token, receipt)
+	}
+
 	if !token.AllowSwapoutFromContract &&
 		!b.ChainConfig.AllowCallByContract &&
 		!common.IsEqualIgnoreCase(swapInfo.TxTo, token.ContractAddress) {
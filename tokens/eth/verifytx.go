@@ -37,6 +37,83 @@ func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
 	return txStatus, nil
 }
 
+// GetTransactionStatusOf get tx status from specified api address
+func (b *Bridge) GetTransactionStatusOf(apiAddress, txHash string) (*tokens.TxStatus, error) {
+	txr, err := b.getTransactionReceipt(txHash, []string{apiAddress})
+	if err != nil {
+		return nil, err
+	}
+	txStatus := &tokens.TxStatus{}
+	txStatus.Receipt = txr
+	txStatus.BlockHeight = txr.BlockNumber.ToInt().Uint64()
+	txStatus.BlockHash = txr.BlockHash.String()
+
+	latest, err := b.GetLatestBlockNumberOf(apiAddress)
+	if err != nil {
+		return nil, err
+	}
+	if latest > txStatus.BlockHeight {
+		txStatus.Confirmations = latest - txStatus.BlockHeight
+	}
+	return txStatus, nil
+}
+
+// VerifyTransactionOf verify swap tx through specified api address,
+// require the tx is stable and succeeded, and the matched deposit
+// (or swapout log) is the same as the verified swap info.
+func (b *Bridge) VerifyTransactionOf(apiAddress string, swapInfo *tokens.TxSwapInfo) (*tokens.TxStatus, error) {
+	txStatus, err := b.GetTransactionStatusOf(apiAddress, swapInfo.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if txStatus.BlockHeight == 0 || txStatus.Confirmations < *b.GetChainConfig().Confirmations {
+		return txStatus, tokens.ErrTxNotStable
+	}
+	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	if !ok || !receipt.IsStatusOk() {
+		return txStatus, tokens.ErrTxWithWrongReceipt
+	}
+	token := b.GetTokenConfig(swapInfo.PairID)
+	if token == nil {
+		return txStatus, tokens.ErrUnknownPairID
+	}
+	verified := &tokens.TxSwapInfo{
+		PairID:   swapInfo.PairID,
+		Hash:     swapInfo.Hash,
+		Height:   txStatus.BlockHeight,
+		LogIndex: swapInfo.LogIndex,
+	}
+	switch {
+	case !b.IsSrc:
+		err = b.verifySwapoutTxReceipt(verified, receipt, token)
+	case token.IsErc20():
+		err = b.verifyErc20SwapinTxReceipt(verified, receipt, token)
+	default:
+		var tx *types.RPCTransaction
+		tx, err = b.getTransactionByHash(swapInfo.Hash, []string{apiAddress})
+		if err == nil {
+			_, err = b.verifyNativeSwapinTx(verified, true, token, tx)
+		}
+	}
+	if err != nil {
+		return txStatus, err
+	}
+	if !isSameSwapInfo(swapInfo, verified) {
+		log.Warn("swap info mismatch with gateway", "apiAddress", apiAddress, "txid", swapInfo.Hash,
+			"from", verified.From, "to", verified.To, "bind", verified.Bind, "value", verified.Value)
+		return txStatus, tokens.ErrTxInfoMismatch
+	}
+	return txStatus, nil
+}
+
+func isSameSwapInfo(a, b *tokens.TxSwapInfo) bool {
+	return strings.EqualFold(a.From, b.From) &&
+		strings.EqualFold(a.TxTo, b.TxTo) &&
+		strings.EqualFold(a.To, b.To) &&
+		strings.EqualFold(a.Bind, b.Bind) &&
+		a.Value != nil && b.Value != nil && a.Value.Cmp(b.Value) == 0
+}
+
 // VerifyMsgHash verify msg hash
 func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
 	tx, ok := rawTx.(*types.Transaction)
@@ -69,8 +146,14 @@ func getTxByHash(b *Bridge, txHash string, withExt bool) (*types.RPCTransaction,
 // VerifyTransaction impl
 func (b *Bridge) VerifyTransaction(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
 	swapInfo := &tokens.TxSwapInfo{}
-	swapInfo.PairID = pairID                // PairID
-	swapInfo.Hash = strings.ToLower(txHash) // Hash
+	swapInfo.PairID = pairID // PairID
+
+	hash, logIndex, err := tokens.ParseSwapID(txHash)
+	if err != nil {
+		return swapInfo, err
+	}
+	swapInfo.Hash = strings.ToLower(hash) // Hash
+	swapInfo.LogIndex = logIndex          // LogIndex
 
 	token := b.GetTokenConfig(pairID)
 
@@ -78,6 +161,10 @@ func (b *Bridge) VerifyTransaction(pairID, txHash string, allowUnstable bool) (*
 		return swapInfo, tokens.ErrUnknownPairID
 	}
 
+	if logIndex != 0 && (!b.IsSrc || !token.IsErc20()) {
+		return swapInfo, tokens.ErrWrongSwapID
+	}
+
 	if token.DisableSwap {
 		return swapInfo, tokens.ErrSwapIsClosed
 	}
@@ -109,8 +196,12 @@ func (b *Bridge) verifyNativeSwapinTx(swapInfo *tokens.TxSwapInfo, allowUnstable
 	}
 
 	txRecipient := strings.ToLower(tx.Recipient.String())
+	var depositBind string
 	if !common.IsEqualIgnoreCase(txRecipient, token.DepositAddress) {
-		return swapInfo, tokens.ErrTxWithWrongReceiver
+		depositBind = b.getDepositAddressBind(token, swapInfo.PairID, txRecipient)
+		if depositBind == "" {
+			return swapInfo, tokens.ErrTxWithWrongReceiver
+		}
 	}
 	if *tx.From == (common.Address{}) {
 		return nil, tokens.ErrTxWithWrongSender
@@ -122,11 +213,19 @@ func (b *Bridge) verifyNativeSwapinTx(swapInfo *tokens.TxSwapInfo, allowUnstable
 	swapInfo.Bind = swapInfo.From                     // Bind
 	swapInfo.Value = tx.Amount.ToInt()                // Value
 
+	if depositBind != "" {
+		swapInfo.Bind = depositBind // Bind
+	}
+
 	err := b.checkSwapinInfo(swapInfo)
 	if err != nil {
 		return swapInfo, err
 	}
 
+	if tx.Payload != nil && isSwapCallEnabled(swapInfo.PairID) {
+		swapInfo.Call = parseSwapCall(swapInfo.PairID, *tx.Payload)
+	}
+
 	if !allowUnstable {
 		log.Info("verify native swapin stable pass",
 			"identifier", params.GetIdentifier(), "pairID", swapInfo.PairID,
@@ -454,11 +454,13 @@ func constructSwapinTxTest(t *testing.T, args []string, wantErr error) *verifyTx
 		AllowSwapinFromContract: allowCallFromContract,
 	}
 
+	logIndex := hexutil.Uint(0)
 	log := &types.RPCLog{
-		Address: logAddr,
-		Topics:  topics,
-		Data:    &logData,
-		Removed: &removed,
+		Address:  logAddr,
+		Topics:   topics,
+		Data:     &logData,
+		Removed:  &removed,
+		LogIndex: &logIndex,
 	}
 
 	test.receipt = &types.RPCTxReceipt{
@@ -488,11 +490,13 @@ func constructSwapoutTxTest(t *testing.T, args []string, wantErr error, isSwapou
 		AllowSwapoutFromContract: allowCallFromContract,
 	}
 
+	logIndex := hexutil.Uint(0)
 	log := &types.RPCLog{
-		Address: logAddr,
-		Topics:  topics,
-		Data:    &logData,
-		Removed: &removed,
+		Address:  logAddr,
+		Topics:   topics,
+		Data:     &logData,
+		Removed:  &removed,
+		LogIndex: &logIndex,
 	}
 
 	test.receipt = &types.RPCTxReceipt{
@@ -590,3 +594,38 @@ func completeTokenConfig(token *tokens.TokenConfig) {
 
 	tokens.SetTokenPairsConfig(pairsConfig, false)
 }
+
+func TestIsSameSwapInfo(t *testing.T) {
+	newSwapInfo := func() *tokens.TxSwapInfo {
+		return &tokens.TxSwapInfo{
+			From:  "0x1111111111111111111111111111111111111111",
+			TxTo:  "0x2222222222222222222222222222222222222222",
+			To:    "0x3333333333333333333333333333333333333333",
+			Bind:  "0x1111111111111111111111111111111111111111",
+			Value: common.BigFromUint64(1000),
+		}
+	}
+	swapInfo := newSwapInfo()
+
+	same := newSwapInfo()
+	same.From = strings.ToUpper(same.From)
+	if !isSameSwapInfo(swapInfo, same) {
+		t.Errorf("want same swap info ignoring case")
+	}
+
+	modifiers := []func(*tokens.TxSwapInfo){
+		func(s *tokens.TxSwapInfo) { s.From = "0x4444444444444444444444444444444444444444" },
+		func(s *tokens.TxSwapInfo) { s.TxTo = "0x4444444444444444444444444444444444444444" },
+		func(s *tokens.TxSwapInfo) { s.To = "0x4444444444444444444444444444444444444444" },
+		func(s *tokens.TxSwapInfo) { s.Bind = "0x4444444444444444444444444444444444444444" },
+		func(s *tokens.TxSwapInfo) { s.Value = common.BigFromUint64(999) },
+		func(s *tokens.TxSwapInfo) { s.Value = nil },
+	}
+	for i, modify := range modifiers {
+		other := newSwapInfo()
+		modify(other)
+		if isSameSwapInfo(swapInfo, other) {
+			t.Errorf("test %v: want different swap info", i)
+		}
+	}
+}
@@ -39,6 +39,13 @@ type NonceSetter interface {
 	InitNonces(nonces map[string]uint64)
 }
 
+// NonceReserver reserve nonces ahead for pre-signing (for eth-like)
+type NonceReserver interface {
+	ReserveNonce(pairID string, value uint64) (nonce uint64)
+	ReleaseNonce(pairID string, nonce uint64)
+	IsNonceReserved(pairID string, nonce uint64) bool
+}
+
 // ForkChecker fork checker interface
 type ForkChecker interface {
 	GetBlockHashOf(urls []string, height uint64) (hash string, err error)
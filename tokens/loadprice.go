@@ -70,12 +70,17 @@ func (c *TokenPairConfig) loadTokenPrice() (err error) {
 }
 
 func loadTokenPrice(chainID *big.Int, tokenAddress string) (float64, error) {
+	return LoadTokenPrice(TokenPriceCfg, chainID, tokenAddress)
+}
+
+// LoadTokenPrice load token price from price feed contract
+func LoadTokenPrice(priceCfg *TokenPriceConfig, chainID *big.Int, tokenAddress string) (float64, error) {
 	// call `getTokenPrice(uint256 chainID, address tokenAddr)`
 	data := make(hexutil.Bytes, 68)
 	copy(data[:4], common.FromHex("0x87e320e4"))
 	copy(data[4:36], common.LeftPadBytes(chainID.Bytes(), 32))
 	copy(data[36:], common.HexToAddress(tokenAddress).Hash().Bytes())
-	result, err := callContract(TokenPriceCfg.Contract, TokenPriceCfg.APIAddress, data, "latest")
+	result, err := callContract(priceCfg.Contract, priceCfg.APIAddress, data, "latest")
 	if err != nil {
 		log.Error("load token price failed", "chainID", chainID, "token", tokenAddress, "err", err)
 		return 0, err
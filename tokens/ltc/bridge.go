@@ -9,6 +9,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 )
 
 const (
@@ -23,6 +24,7 @@ var PairID = "ltc"
 // Bridge ltc bridge
 type Bridge struct {
 	*tokens.CrossChainBridgeBase
+	gateway electrs.Gateway
 }
 
 var instance *Bridge
@@ -34,7 +36,7 @@ func NewCrossChainBridge(isSrc bool) *Bridge {
 		log.Fatalf("ltc::NewCrossChainBridge error %v", tokens.ErrBridgeDestinationNotSupported)
 	}
 	btc.PairID = PairID
-	instance = &Bridge{tokens.NewCrossChainBridgeBase(isSrc)}
+	instance = &Bridge{CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc)}
 	btc.BridgeInstance = instance
 	return instance
 }
@@ -43,6 +45,7 @@ func NewCrossChainBridge(isSrc bool) *Bridge {
 func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
 	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
 	b.VerifyChainConfig()
+	b.gateway = electrs.NewGateway(b)
 	b.InitLatestBlockNumber()
 }
 
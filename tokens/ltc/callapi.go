@@ -9,17 +9,17 @@ import (
 
 // GetLatestBlockNumberOf impl
 func (b *Bridge) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
-	return electrs.GetLatestBlockNumberOf(apiAddress)
+	return b.gateway.GetLatestBlockNumberOf(apiAddress)
 }
 
 // GetLatestBlockNumber impl
 func (b *Bridge) GetLatestBlockNumber() (uint64, error) {
-	return electrs.GetLatestBlockNumber(b)
+	return b.gateway.GetLatestBlockNumber()
 }
 
 // GetTransactionByHash impl
 func (b *Bridge) GetTransactionByHash(txHash string) (*electrs.ElectTx, error) {
-	result, err := electrs.GetTransactionByHash(b, txHash)
+	result, err := b.gateway.GetTransactionByHash(txHash)
 	if err == nil {
 		*result = *b.ToLTCTx(result)
 	}
@@ -28,30 +28,22 @@ func (b *Bridge) GetTransactionByHash(txHash string) (*electrs.ElectTx, error) {
 
 // GetElectTransactionStatus impl
 func (b *Bridge) GetElectTransactionStatus(txHash string) (*electrs.ElectTxStatus, error) {
-	return electrs.GetElectTransactionStatus(b, txHash)
+	return b.gateway.GetElectTransactionStatus(txHash)
 }
 
 // FindUtxos impl
 func (b *Bridge) FindUtxos(addr string) ([]*electrs.ElectUtxo, error) {
-	btcaddr, cvterr := b.ConvertLTCAddress(addr, "")
-	if cvterr == nil {
-		addr = btcaddr.String()
-	}
-	return electrs.FindUtxos(b, addr)
+	return b.gateway.FindUtxos(addr)
 }
 
 // GetPoolTxidList impl
 func (b *Bridge) GetPoolTxidList() ([]string, error) {
-	return electrs.GetPoolTxidList(b)
+	return b.gateway.GetPoolTxidList()
 }
 
 // GetPoolTransactions impl
 func (b *Bridge) GetPoolTransactions(addr string) ([]*electrs.ElectTx, error) {
-	btcaddr, cvterr := b.ConvertLTCAddress(addr, "")
-	if cvterr == nil {
-		addr = btcaddr.String()
-	}
-	results, err := electrs.GetPoolTransactions(b, addr)
+	results, err := b.gateway.GetPoolTransactions(addr)
 	if err == nil {
 		for _, result := range results {
 			*result = *b.ToLTCTx(result)
@@ -62,11 +54,7 @@ func (b *Bridge) GetPoolTransactions(addr string) ([]*electrs.ElectTx, error) {
 
 // GetTransactionHistory impl
 func (b *Bridge) GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.ElectTx, error) {
-	btcaddr, cvterr := b.ConvertLTCAddress(addr, "")
-	if cvterr == nil {
-		addr = btcaddr.String()
-	}
-	results, err := electrs.GetTransactionHistory(b, addr, lastSeenTxid)
+	results, err := b.gateway.GetTransactionHistory(addr, lastSeenTxid)
 	if err == nil {
 		for _, result := range results {
 			*result = *b.ToLTCTx(result)
@@ -77,32 +65,32 @@ func (b *Bridge) GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.El
 
 // GetOutspend impl
 func (b *Bridge) GetOutspend(txHash string, vout uint32) (*electrs.ElectOutspend, error) {
-	return electrs.GetOutspend(b, txHash, vout)
+	return b.gateway.GetOutspend(txHash, vout)
 }
 
 // PostTransaction impl
 func (b *Bridge) PostTransaction(txHex string) (txHash string, err error) {
-	return electrs.PostTransaction(b, txHex)
+	return b.gateway.PostTransaction(txHex)
 }
 
 // GetBlockHash impl
 func (b *Bridge) GetBlockHash(height uint64) (string, error) {
-	return electrs.GetBlockHash(b, height)
+	return b.gateway.GetBlockHash(height)
 }
 
 // GetBlockTxids impl
 func (b *Bridge) GetBlockTxids(blockHash string) ([]string, error) {
-	return electrs.GetBlockTxids(b, blockHash)
+	return b.gateway.GetBlockTxids(blockHash)
 }
 
 // GetBlock impl
 func (b *Bridge) GetBlock(blockHash string) (*electrs.ElectBlock, error) {
-	return electrs.GetBlock(b, blockHash)
+	return b.gateway.GetBlock(blockHash)
 }
 
 // GetBlockTransactions impl
 func (b *Bridge) GetBlockTransactions(blockHash string, startIndex uint32) ([]*electrs.ElectTx, error) {
-	results, err := electrs.GetBlockTransactions(b, blockHash, startIndex)
+	results, err := b.gateway.GetBlockTransactions(blockHash, startIndex)
 	if err == nil {
 		for _, result := range results {
 			*result = *b.ToLTCTx(result)
@@ -113,7 +101,7 @@ func (b *Bridge) GetBlockTransactions(blockHash string, startIndex uint32) ([]*e
 
 // EstimateFeePerKb impl
 func (b *Bridge) EstimateFeePerKb(blocks int) (int64, error) {
-	return electrs.EstimateFeePerKb(b, blocks)
+	return b.gateway.EstimateFeePerKb(blocks)
 }
 
 // GetBalance impl
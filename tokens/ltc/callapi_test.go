@@ -0,0 +1,85 @@
+package ltc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcutil"
+)
+
+// TestFindUtxosByBitcoindGateway test ltc address is passed to non-REST gateway as is
+func TestFindUtxosByBitcoindGateway(t *testing.T) {
+	pubKeyHash := bytes.Repeat([]byte{0x11}, 20)
+	addr, err := ltcutil.NewAddressPubKeyHash(pubKeyHash, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("new ltc address failed: %v", err)
+	}
+	wantDescriptor := "raw(76a914" + hex.EncodeToString(pubKeyHash) + "88ac)"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}       `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resp := map[string]interface{}{"id": req.ID, "error": nil}
+		switch req.Method {
+		case "getblockcount":
+			resp["result"] = 100
+		case "scantxoutset":
+			var descriptors []string
+			if len(req.Params) == 2 {
+				_ = json.Unmarshal(req.Params[1], &descriptors)
+			}
+			if len(descriptors) != 1 || descriptors[0] != wantDescriptor {
+				resp["error"] = map[string]interface{}{"code": -8, "message": "wrong descriptor " + strings.Join(descriptors, ",")}
+				break
+			}
+			resp["result"] = map[string]interface{}{
+				"success":  true,
+				"height":   100,
+				"unspents": []interface{}{map[string]interface{}{"txid": strings.Repeat("ab", 32), "vout": 1, "amount": 0.5, "height": 90}},
+			}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	b := &Bridge{CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(true)}
+	b.ChainConfig = &tokens.ChainConfig{NetID: netMainnet}
+	b.GatewayConfig = &tokens.GatewayConfig{
+		Extras: &tokens.GatewayExtras{
+			BtcExtra: &tokens.BtcGatewayExtraArgs{
+				GatewayType: electrs.BitcoindGatewayType,
+				CoreAPIs: []tokens.BlocknetCoreAPIArgs{
+					{APIAddress: strings.TrimPrefix(server.URL, "http://"), RPCUser: "user", RPCPassword: "pass", DisableTLS: true},
+				},
+			},
+		},
+	}
+	b.gateway = electrs.NewGateway(b)
+
+	utxos, err := b.FindUtxos(addr.EncodeAddress())
+	if err != nil {
+		t.Fatalf("find utxos of ltc address %v failed: %v", addr.EncodeAddress(), err)
+	}
+	if len(utxos) != 1 || *utxos[0].Value != 50000000 || *utxos[0].Vout != 1 {
+		t.Errorf("wrong utxos of ltc address %v", addr.EncodeAddress())
+	}
+
+	// electrum gateway decodes address to script hash by ltc bridge too
+	if _, err = electrs.GetAddressScriptHash(b, addr.EncodeAddress()); err != nil {
+		t.Errorf("get script hash of ltc address failed: %v", err)
+	}
+}
@@ -1,10 +1,12 @@
 package ltc
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
@@ -20,6 +22,10 @@ func (b *Bridge) StartPoolTransactionScanJob() {
 	scanSubject := fmt.Sprintf("[scanpool] scanned %v tx", chainName)
 	for {
 		txids, err := b.GetPoolTxidList()
+		if errors.Is(err, electrs.ErrNotSupportedByGateway) {
+			log.Warnf("[scanpool] stop scan %v tx pool job as %v", chainName, err)
+			return
+		}
 		if err != nil {
 			log.Error(errorSubject, "err", err)
 			time.Sleep(retryIntervalInScanJob)
@@ -1,10 +1,12 @@
 package ltc
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
@@ -40,6 +42,10 @@ func (b *Bridge) scanFirstLoop() {
 FIRST_LOOP:
 	for {
 		txHistory, err := b.GetTransactionHistory(tokenCfg.DepositAddress, lastSeenTxid)
+		if errors.Is(err, electrs.ErrNotSupportedByGateway) {
+			log.Warnf("[scanFirstLoop] stop %v first scan loop as %v", chainName, err)
+			return
+		}
 		if err != nil {
 			time.Sleep(retryIntervalInScanJob)
 			continue
@@ -89,6 +95,10 @@ func (b *Bridge) scanTransactionHistory() {
 
 	for {
 		txHistory, err := b.GetTransactionHistory(tokenCfg.DepositAddress, lastSeenTxid)
+		if errors.Is(err, electrs.ErrNotSupportedByGateway) {
+			log.Warnf("[scanhistory] stop %v scan swap history loop as %v", chainName, err)
+			return
+		}
 		if err != nil {
 			log.Error(errorSubject, "err", err)
 			time.Sleep(retryIntervalInScanJob)
@@ -226,7 +226,7 @@ func (b *Bridge) DcrmSignMsgHash(msgHash []string, args *tokens.BuildTxArgs) (rs
 	msgContext := []string{string(jsondata)}
 
 	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msgContext", msgContext, "txid", args.SwapID)
-	keyID, rsv, err := dcrm.DoSignEC(cfgFromPublicKey, msgHash, msgContext)
+	keyID, rsv, err := tokens.DoSign(b.GetTokenConfig(args.PairID), dcrm.SignTypeEC256K1, cfgFromPublicKey, msgHash, msgContext)
 	if err != nil {
 		log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction failed", "keyID", keyID, "msghash", msgHash, "txid", args.SwapID, "err", err)
 		return nil, err
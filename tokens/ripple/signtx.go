@@ -66,22 +66,9 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 	pubkey := common.FromHex(pubkeyStr)
 	isEd := isEd25519Pubkey(pubkey)
 
-	var signContent string
-	var signType string
+	signType, signPubkey, signContent := getSignArgs(pubkeyStr, msgHash.String(), msg)
 
-	if isEd {
-		// dcrm ed public key has no 0xed prefix
-		pubkeyStr = pubkeyStr[2:]
-		// the real sign content is (signing prefix + msg)
-		// when we hex encoding here, the dcrm should do hex decoding there.
-		signContent = common.ToHex(msg)
-		signType = dcrm.SignTypeEC256K1
-	} else {
-		signContent = msgHash.String()
-		signType = dcrm.SignTypeED25519
-	}
-
-	keyID, rsvs, err := dcrm.DoSignOne(signType, pubkeyStr, signContent, msgContext)
+	keyID, rsvs, err := tokens.DoSign(b.GetTokenConfig(args.PairID), signType, signPubkey, []string{signContent}, []string{msgContext})
 	if err != nil {
 		return nil, "", err
 	}
@@ -186,6 +173,21 @@ func (b *Bridge) MakeSignedTransaction(pubkey []byte, rsv string, transaction in
 	return tx, nil
 }
 
+// getSignArgs get sign type, public key and content of dcrm signing.
+// ed25519 key must be signed with ed25519 sign type (not ECDSA),
+// otherwise the signature can not be verified by the ed public key.
+func getSignArgs(pubkeyStr, msgHash string, msg []byte) (signType, signPubkey, signContent string) {
+	if isEd25519Pubkey(common.FromHex(pubkeyStr)) {
+		// dcrm ed public key has no 0xed prefix
+		signPubkey = pubkeyStr[2:]
+		// the real sign content is (signing prefix + msg)
+		// when we hex encoding here, the dcrm should do hex decoding there.
+		signContent = common.ToHex(msg)
+		return dcrm.SignTypeED25519, signPubkey, signContent
+	}
+	return dcrm.SignTypeEC256K1, pubkeyStr, msgHash
+}
+
 func isEd25519Pubkey(pubkey []byte) bool {
 	return len(pubkey) == ed25519.PublicKeySize+1 && pubkey[0] == 0xED
 }
@@ -0,0 +1,46 @@
+package ripple
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	rcrypto "github.com/anyswap/CrossChain-Bridge/tokens/ripple/rubblelabs/ripple/crypto"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func TestGetSignArgs(t *testing.T) {
+	msg := []byte("ripple signing prefix and msg")
+	msgHash := common.Keccak256Hash(msg).String()
+
+	// ed25519 key is signed over msg with ED25519 sign type
+	edPub, edPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPubkeyStr := "ED" + hex.EncodeToString(edPub)
+	signType, signPubkey, signContent := getSignArgs(edPubkeyStr, msgHash, msg)
+	if signType != dcrm.SignTypeED25519 {
+		t.Errorf("ed key want sign type %v, have %v", dcrm.SignTypeED25519, signType)
+	}
+	if signPubkey != hex.EncodeToString(edPub) {
+		t.Errorf("ed key want sign public key without 0xED prefix, have %v", signPubkey)
+	}
+	sig := ed25519.Sign(edPriv, common.FromHex(signContent))
+	if ok, errv := rcrypto.Verify(common.FromHex(edPubkeyStr), common.FromHex(msgHash), msg, sig); !ok || errv != nil {
+		t.Errorf("ed signature of sign content verify failed (valid: %v): %v", ok, errv)
+	}
+
+	// secp256k1 key is signed over msg hash with ECDSA sign type
+	ecPriv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPubkeyStr := hex.EncodeToString(crypto.CompressPubkey(&ecPriv.PublicKey))
+	signType, signPubkey, signContent = getSignArgs(ecPubkeyStr, msgHash, msg)
+	if signType != dcrm.SignTypeEC256K1 || signPubkey != ecPubkeyStr || signContent != msgHash {
+		t.Errorf("ec key want (%v, %v, %v), have (%v, %v, %v)", dcrm.SignTypeEC256K1, ecPubkeyStr, msgHash, signType, signPubkey, signContent)
+	}
+}
@@ -0,0 +1,90 @@
+package tokens
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// signer types
+const (
+	DcrmSignerType     = "dcrm"
+	KeystoreSignerType = "keystore"
+	Pkcs11SignerType   = "pkcs11"
+	RemoteSignerType   = "remote"
+)
+
+// Signer sign msg hashes with the key of dcrm address.
+// the returned rsvs are hex strings of `r || s || v` (v is recovery id 0 or 1)
+// for EC sign type, and in the same order as msgHash.
+type Signer interface {
+	Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error)
+}
+
+// SignerCreator create signer of token
+type SignerCreator func(token *TokenConfig) (Signer, error)
+
+var (
+	signerCreators = make(map[string]SignerCreator)
+
+	signerLock sync.Mutex
+)
+
+// RegisterSignerCreator register signer creator of signer type
+func RegisterSignerCreator(signerType string, creator SignerCreator) {
+	signerCreators[signerType] = creator
+}
+
+// GetSignerType get signer type
+func (c *TokenConfig) GetSignerType() string {
+	if c.Signer == nil || c.Signer.Type == "" {
+		return DcrmSignerType
+	}
+	return c.Signer.Type
+}
+
+// GetSigner get signer (create it if not exist)
+func (c *TokenConfig) GetSigner() (Signer, error) {
+	signerLock.Lock()
+	defer signerLock.Unlock()
+
+	if c.signer != nil {
+		return c.signer, nil
+	}
+	signerType := c.GetSignerType()
+	creator, exist := signerCreators[signerType]
+	if !exist {
+		return nil, fmt.Errorf("unsupported signer type '%v'", signerType)
+	}
+	signer, err := creator(c)
+	if err != nil {
+		return nil, fmt.Errorf("create %v signer failed: %w", signerType, err)
+	}
+	c.signer = signer
+	return signer, nil
+}
+
+// DoSign sign msg hashes with the configed signer of token
+func DoSign(token *TokenConfig, signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	if token == nil {
+		return "", nil, ErrUnknownPairID
+	}
+	signer, err := token.GetSigner()
+	if err != nil {
+		return "", nil, err
+	}
+	return signer.Sign(signType, signPubkey, msgHash, msgContext)
+}
+
+// InitSigners create signers of all token pairs
+func InitSigners() {
+	for _, pairCfg := range GetTokenPairsConfig() {
+		for _, token := range []*TokenConfig{pairCfg.SrcToken, pairCfg.DestToken} {
+			if _, err := token.GetSigner(); err != nil {
+				log.Fatal("init signer failed", "pairID", pairCfg.PairID, "symbol", token.Symbol, "signerType", token.GetSignerType(), "err", err)
+			}
+			log.Info("init signer success", "pairID", pairCfg.PairID, "symbol", token.Symbol, "signerType", token.GetSignerType())
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package signer
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// dcrmSigner sign with dcrm rpc
+type dcrmSigner struct{}
+
+func newDcrmSigner(token *tokens.TokenConfig) (tokens.Signer, error) {
+	return &dcrmSigner{}, nil
+}
+
+// Sign impl
+func (s *dcrmSigner) Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	return dcrm.DoSign(signType, signPubkey, msgHash, msgContext)
+}
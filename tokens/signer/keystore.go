@@ -0,0 +1,57 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+// keystoreSigner sign with private key in encrypted keystore file
+type keystoreSigner struct {
+	keyID   string
+	privKey *ecdsa.PrivateKey
+	pubkey  []byte
+}
+
+func newKeystoreSigner(token *tokens.TokenConfig) (tokens.Signer, error) {
+	pubkey, err := getTokenPublicKey(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := tools.LoadKeyStore(token.Signer.KeystoreFile, token.Signer.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(crypto.FromECDSAPub(&key.PrivateKey.PublicKey), pubkey) {
+		return nil, errors.New("keystore public key and 'DcrmPubkey' mismatch")
+	}
+	return &keystoreSigner{
+		keyID:   fmt.Sprintf("keystore:%v", key.Address.String()),
+		privKey: key.PrivateKey,
+		pubkey:  pubkey,
+	}, nil
+}
+
+// Sign impl
+func (s *keystoreSigner) Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	err = checkSignArgs(signType, signPubkey, s.pubkey, msgHash, msgContext)
+	if err != nil {
+		return "", nil, err
+	}
+	rsvs = make([]string, len(msgHash))
+	for i, hash := range msgHash {
+		sig, errf := crypto.Sign(common.FromHex(hash), s.privKey)
+		if errf != nil {
+			return "", nil, errf
+		}
+		rsvs[i] = hex.EncodeToString(sig)
+	}
+	return s.keyID, rsvs, nil
+}
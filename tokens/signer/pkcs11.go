@@ -0,0 +1,133 @@
+//go:build pkcs11
+// +build pkcs11
+
+package signer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools"
+	"github.com/miekg/pkcs11"
+)
+
+// pkcs11Signer sign with secp256k1 private key stored in HSM through pkcs11
+type pkcs11Signer struct {
+	keyID   string
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	privKey pkcs11.ObjectHandle
+	pubkey  []byte
+
+	lock sync.Mutex
+}
+
+func newPkcs11Signer(token *tokens.TokenConfig) (tokens.Signer, error) {
+	pubkey, err := getTokenPublicKey(token)
+	if err != nil {
+		return nil, err
+	}
+	cfg := token.Signer
+	pindata, err := tools.SafeReadFile(cfg.Pkcs11PinFile)
+	if err != nil {
+		return nil, fmt.Errorf("read pin fail %w", err)
+	}
+	ctx := pkcs11.New(cfg.Pkcs11Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("load pkcs11 module '%v' failed", cfg.Pkcs11Module)
+	}
+	signer := &pkcs11Signer{
+		keyID:  fmt.Sprintf("pkcs11:%v:%v", cfg.Pkcs11TokenLabel, cfg.Pkcs11KeyLabel),
+		ctx:    ctx,
+		pubkey: pubkey,
+	}
+	err = signer.open(cfg.Pkcs11TokenLabel, cfg.Pkcs11KeyLabel, strings.TrimSpace(string(pindata)))
+	if err != nil {
+		ctx.Destroy()
+		return nil, err
+	}
+	return signer, nil
+}
+
+func (s *pkcs11Signer) open(tokenLabel, keyLabel, pin string) (err error) {
+	if err = s.ctx.Initialize(); err != nil {
+		return err
+	}
+	slots, err := s.ctx.GetSlotList(true)
+	if err != nil {
+		return err
+	}
+	var slotID uint
+	found := false
+	for _, slot := range slots {
+		tokenInfo, errf := s.ctx.GetTokenInfo(slot)
+		if errf == nil && strings.TrimSpace(tokenInfo.Label) == tokenLabel {
+			slotID, found = slot, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("pkcs11 token '%v' not found", tokenLabel)
+	}
+	s.session, err = s.ctx.OpenSession(slotID, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return err
+	}
+	if err = s.ctx.Login(s.session, pkcs11.CKU_USER, pin); err != nil {
+		return err
+	}
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	if err = s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return err
+	}
+	objs, _, err := s.ctx.FindObjects(s.session, 1)
+	_ = s.ctx.FindObjectsFinal(s.session)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		return fmt.Errorf("pkcs11 key '%v' not found", keyLabel)
+	}
+	s.privKey = objs[0]
+	return nil
+}
+
+// Sign impl
+func (s *pkcs11Signer) Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	err = checkSignArgs(signType, signPubkey, s.pubkey, msgHash, msgContext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}
+	rsvs = make([]string, len(msgHash))
+	for i, hash := range msgHash {
+		hashData := common.FromHex(hash)
+		if err = s.ctx.SignInit(s.session, mechanism, s.privKey); err != nil {
+			return "", nil, err
+		}
+		// CKM_ECDSA signature is `r || s` of curve order length
+		sig, errf := s.ctx.Sign(s.session, hashData)
+		if errf != nil {
+			return "", nil, errf
+		}
+		if len(sig) != 64 {
+			return "", nil, errWrongSignatureLength
+		}
+		rsvs[i], err = makeRsv(hashData, sig[:32], sig[32:], s.pubkey)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return s.keyID, rsvs, nil
+}
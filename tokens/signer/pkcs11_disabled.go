@@ -0,0 +1,14 @@
+//go:build !pkcs11
+// +build !pkcs11
+
+package signer
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+func newPkcs11Signer(token *tokens.TokenConfig) (tokens.Signer, error) {
+	return nil, errors.New("pkcs11 signer is not supported, please build with '-tags pkcs11'")
+}
@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	remoteSignMethod = "signer_sign"
+
+	defaultRemoteSignTimeout = 60 // seconds
+)
+
+// RemoteSignArgs remote sign args (json rpc method `signer_sign`)
+type RemoteSignArgs struct {
+	SignType   string   `json:"signType"`
+	SignPubkey string   `json:"signPubkey"`
+	MsgHash    []string `json:"msgHash"`
+	MsgContext []string `json:"msgContext"`
+}
+
+// RemoteSignResult remote sign result
+type RemoteSignResult struct {
+	KeyID string   `json:"keyID"`
+	Rsvs  []string `json:"rsvs"`
+}
+
+// remoteSigner sign by calling remote signer service over http
+type remoteSigner struct {
+	apiAddress string
+	timeout    int
+	pubkey     []byte
+}
+
+func newRemoteSigner(token *tokens.TokenConfig) (tokens.Signer, error) {
+	timeout := token.Signer.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRemoteSignTimeout
+	}
+	return &remoteSigner{
+		apiAddress: token.Signer.APIAddress,
+		timeout:    timeout,
+		pubkey:     common.FromHex(token.DcrmPubkey),
+	}, nil
+}
+
+// Sign impl
+func (s *remoteSigner) Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	args := &RemoteSignArgs{
+		SignType:   signType,
+		SignPubkey: signPubkey,
+		MsgHash:    msgHash,
+		MsgContext: msgContext,
+	}
+	var result RemoteSignResult
+	err = client.RPCPostWithTimeout(s.timeout, &result, s.apiAddress, remoteSignMethod, args)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(result.Rsvs) != len(msgHash) {
+		return "", nil, fmt.Errorf("remote sign require %v rsv but have %v (keyID = %v)", len(msgHash), len(result.Rsvs), result.KeyID)
+	}
+	// do not trust remote signer, verify the ECDSA signatures
+	if isEC(signType) && len(s.pubkey) == 65 {
+		for i, rsv := range result.Rsvs {
+			err = verifyRsv(common.FromHex(msgHash[i]), rsv, s.pubkey)
+			if err != nil {
+				return "", nil, fmt.Errorf("verify remote sign result failed: %w (keyID = %v)", err, result.KeyID)
+			}
+		}
+	}
+	return result.KeyID, result.Rsvs, nil
+}
@@ -0,0 +1,94 @@
+// Package signer implements signers of dcrm address besides dcrm,
+// including encrypted keystore, pkcs11 HSM and remote signer.
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+var (
+	errOnlySupportEC        = errors.New("signer only support EC sign type")
+	errSignPubkeyMismatch   = errors.New("sign public key mismatch")
+	errWrongSignatureLength = errors.New("wrong signature length")
+	errNoMatchedRecoveryID  = errors.New("no matched recovery id")
+)
+
+func init() {
+	tokens.RegisterSignerCreator(tokens.DcrmSignerType, withVerify(newDcrmSigner))
+	tokens.RegisterSignerCreator(tokens.KeystoreSignerType, withVerify(newKeystoreSigner))
+	tokens.RegisterSignerCreator(tokens.Pkcs11SignerType, withVerify(newPkcs11Signer))
+	tokens.RegisterSignerCreator(tokens.RemoteSignerType, withVerify(newRemoteSigner))
+}
+
+func isEC(signType string) bool {
+	return signType == "" || signType == dcrm.SignTypeEC256K1
+}
+
+func getTokenPublicKey(token *tokens.TokenConfig) ([]byte, error) {
+	pubkey := common.FromHex(token.DcrmPubkey)
+	if len(pubkey) != 65 || pubkey[0] != 4 {
+		return nil, errors.New("token must config uncompressed 'DcrmPubkey'")
+	}
+	return pubkey, nil
+}
+
+func checkSignArgs(signType, signPubkey string, pubkey []byte, msgHash, msgContext []string) error {
+	if !isEC(signType) {
+		return errOnlySupportEC
+	}
+	if !bytes.Equal(common.FromHex(signPubkey), pubkey) {
+		return errSignPubkeyMismatch
+	}
+	if len(msgContext) > 1 && len(msgContext) != len(msgHash) {
+		return fmt.Errorf("msgHash count %v and msgContext count %v mismatch", len(msgHash), len(msgContext))
+	}
+	return nil
+}
+
+// makeRsv make `r || s || v` signature with low s value,
+// and find the recovery id v by recovering the public key.
+func makeRsv(hash, r, s, pubkey []byte) (string, error) {
+	curveN := crypto.S256().Params().N
+	halfN := new(big.Int).Rsh(curveN, 1)
+	sValue := new(big.Int).SetBytes(s)
+	if sValue.Cmp(halfN) > 0 {
+		sValue.Sub(curveN, sValue)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig[32-len(r):32], r)
+	sBytes := sValue.Bytes()
+	copy(sig[64-len(sBytes):64], sBytes)
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.Ecrecover(hash, sig)
+		if err == nil && bytes.Equal(recovered, pubkey) {
+			return hex.EncodeToString(sig), nil
+		}
+	}
+	return "", errNoMatchedRecoveryID
+}
+
+// verifyRsv verify `r || s || v` signature is signed by public key
+func verifyRsv(hash []byte, rsv string, pubkey []byte) error {
+	sig := common.FromHex(rsv)
+	if len(sig) != crypto.SignatureLength {
+		return errWrongSignatureLength
+	}
+	recovered, err := crypto.Ecrecover(hash, sig)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(recovered, pubkey) {
+		return errSignPubkeyMismatch
+	}
+	return nil
+}
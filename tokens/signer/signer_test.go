@@ -0,0 +1,124 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func TestMakeRsv(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := crypto.FromECDSAPub(&privKey.PublicKey)
+	hash := common.Keccak256Hash([]byte("test make rsv")).Bytes()
+
+	sig, err := crypto.Sign(hash, privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// HSM may return high s value, which should be normalized
+	curveN := crypto.S256().Params().N
+	highS := new(big.Int).Sub(curveN, new(big.Int).SetBytes(sig[32:64]))
+
+	for _, s := range [][]byte{sig[32:64], highS.Bytes()} {
+		rsv, err := makeRsv(hash, sig[:32], s, pubkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rsv != hex.EncodeToString(sig) {
+			t.Errorf("makeRsv mismatch, have %v want %x", rsv, sig)
+		}
+		if err := verifyRsv(hash, rsv, pubkey); err != nil {
+			t.Errorf("verifyRsv failed: %v", err)
+		}
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := crypto.FromECDSAPub(&privKey.PublicKey)
+	signer := &keystoreSigner{privKey: privKey, pubkey: pubkey}
+
+	msgHash := []string{
+		common.Keccak256Hash([]byte("msg1")).String(),
+		common.Keccak256Hash([]byte("msg2")).String(),
+	}
+	_, rsvs, err := signer.Sign(dcrm.SignTypeEC256K1, hex.EncodeToString(pubkey), msgHash, []string{"context"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rsv := range rsvs {
+		if err := verifyRsv(common.FromHex(msgHash[i]), rsv, pubkey); err != nil {
+			t.Errorf("verify rsv %v failed: %v", i, err)
+		}
+	}
+
+	if _, _, err := signer.Sign(dcrm.SignTypeED25519, hex.EncodeToString(pubkey), msgHash, nil); err != errOnlySupportEC {
+		t.Errorf("sign with ED25519 should fail, have %v", err)
+	}
+	if _, _, err := signer.Sign(dcrm.SignTypeEC256K1, "0x04", msgHash, nil); err != errSignPubkeyMismatch {
+		t.Errorf("sign with wrong public key should fail, have %v", err)
+	}
+}
+
+func TestVerifySignResult(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := hex.EncodeToString(crypto.FromECDSAPub(&privKey.PublicKey))
+	msgHash := []string{
+		common.Keccak256Hash([]byte("msg1")).String(),
+		common.Keccak256Hash([]byte("msg2")).String(),
+	}
+	rsvs := make([]string, len(msgHash))
+	for i, hash := range msgHash {
+		sig, errs := crypto.Sign(common.FromHex(hash), privKey)
+		if errs != nil {
+			t.Fatal(errs)
+		}
+		rsvs[len(rsvs)-1-i] = hex.EncodeToString(sig) // reversed order
+	}
+	orderedRsvs, err := verifySignResult(dcrm.SignTypeEC256K1, pubkey, msgHash, rsvs)
+	if err != nil {
+		t.Errorf("verify EC sign result failed: %v", err)
+	} else if orderedRsvs[0] != rsvs[1] || orderedRsvs[1] != rsvs[0] {
+		t.Errorf("verify EC sign result should reorder rsvs as msg hashes")
+	}
+	otherKey, _ := crypto.GenerateKey()
+	otherPubkey := hex.EncodeToString(crypto.FromECDSAPub(&otherKey.PublicKey))
+	if _, err = verifySignResult(dcrm.SignTypeEC256K1, otherPubkey, msgHash, rsvs); err == nil {
+		t.Errorf("verify EC sign result with wrong public key should fail")
+	}
+	if _, err = verifySignResult(dcrm.SignTypeEC256K1, pubkey, msgHash, rsvs[:1]); err == nil {
+		t.Errorf("verify EC sign result with wrong rsv count should fail")
+	}
+	// the same signature of one msg hash can not be used for the others
+	if _, err = verifySignResult(dcrm.SignTypeEC256K1, pubkey, msgHash, []string{rsvs[0], rsvs[0]}); err == nil {
+		t.Errorf("verify EC sign result with duplicate rsvs should fail")
+	}
+
+	edPub, edPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := common.ToHex([]byte("ed msg"))
+	edSig := hex.EncodeToString(ed25519.Sign(edPriv, []byte("ed msg")))
+	edPubkey := "0xED" + hex.EncodeToString(edPub)
+	if _, err = verifySignResult(dcrm.SignTypeED25519, edPubkey, []string{msg}, []string{edSig}); err != nil {
+		t.Errorf("verify ED sign result failed: %v", err)
+	}
+	if _, err = verifySignResult(dcrm.SignTypeED25519, edPubkey, []string{common.ToHex([]byte("other"))}, []string{edSig}); err == nil {
+		t.Errorf("verify ED sign result with wrong msg should fail")
+	}
+}
@@ -0,0 +1,124 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+var errWrongPublicKey = errors.New("wrong public key")
+
+// verifySigner verify sign results against the configed public key of token,
+// so that a malformed or wrong-key signature is found before broadcasting.
+type verifySigner struct {
+	tokens.Signer
+	token *tokens.TokenConfig
+}
+
+// withVerify wrap signer created by creator with sign result verification
+func withVerify(creator tokens.SignerCreator) tokens.SignerCreator {
+	return func(token *tokens.TokenConfig) (tokens.Signer, error) {
+		signer, err := creator(token)
+		if err != nil {
+			return nil, err
+		}
+		return &verifySigner{Signer: signer, token: token}, nil
+	}
+}
+
+// Sign impl
+func (s *verifySigner) Sign(signType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	keyID, rsvs, err = s.Signer.Sign(signType, signPubkey, msgHash, msgContext)
+	if err != nil {
+		return keyID, rsvs, err
+	}
+	pubkey := s.token.DcrmPubkey
+	if pubkey == "" {
+		pubkey = signPubkey
+	}
+	rsvs, err = verifySignResult(signType, pubkey, msgHash, rsvs)
+	if err != nil {
+		log.Error("verify sign result failed", "keyID", keyID, "signType", signType, "msgHash", msgHash, "rsvs", rsvs, "err", err)
+		_ = mongodb.AddSignFailure(keyID, signType, signPubkey, msgHash, rsvs, err.Error())
+		return keyID, nil, fmt.Errorf("verify signature of keyID %v failed: %w", keyID, err)
+	}
+	return keyID, rsvs, nil
+}
+
+// verifySignResult verify every msg hash is signed by pubkey with a distinct rsv.
+// rsvs may be not in the same order as msgHash (eg. dcrm batch signing),
+// the returned rsvs are reordered so that rsvs[i] is the signature of msgHash[i].
+func verifySignResult(signType, pubkey string, msgHash, rsvs []string) ([]string, error) {
+	if len(rsvs) != len(msgHash) {
+		return nil, fmt.Errorf("require %v rsvs but have %v", len(msgHash), len(rsvs))
+	}
+	var verify func(pkData, msg []byte, rsv string) bool
+	pkData := common.FromHex(pubkey)
+	switch {
+	case isEC(signType):
+		if len(pkData) != 33 && len(pkData) != 65 {
+			return nil, errWrongPublicKey
+		}
+		verify = verifyECSignature
+	case signType == dcrm.SignTypeED25519:
+		if len(pkData) == ed25519.PublicKeySize+1 && pkData[0] == 0xED {
+			pkData = pkData[1:]
+		}
+		if len(pkData) != ed25519.PublicKeySize {
+			return nil, errWrongPublicKey
+		}
+		verify = verifyEDSignature
+	default:
+		return nil, fmt.Errorf("unknown sign type '%v'", signType)
+	}
+	orderedRsvs := make([]string, len(msgHash))
+	used := make([]bool, len(rsvs))
+	for i, hash := range msgHash {
+		for j, rsv := range rsvs {
+			if !used[j] && verify(pkData, common.FromHex(hash), rsv) {
+				orderedRsvs[i] = rsv
+				used[j] = true
+				break
+			}
+		}
+		if orderedRsvs[i] == "" {
+			return nil, fmt.Errorf("msg hash %v is not signed by public key %v", hash, pubkey)
+		}
+	}
+	return orderedRsvs, nil
+}
+
+// verifyECSignature the recovery id v is not checked here (chains may adjust it),
+// and high s value is accepted as chains normalize it when making signed tx.
+func verifyECSignature(pkData, hash []byte, rsv string) bool {
+	sig := common.FromHex(rsv)
+	if len(sig) != crypto.SignatureLength {
+		return false
+	}
+	curveN := crypto.S256().Params().N
+	sValue := new(big.Int).SetBytes(sig[32:64])
+	if sValue.Cmp(new(big.Int).Rsh(curveN, 1)) > 0 {
+		sValue.Sub(curveN, sValue)
+	}
+	rs := make([]byte, 64)
+	copy(rs[:32], sig[:32])
+	sBytes := sValue.Bytes()
+	copy(rs[64-len(sBytes):], sBytes)
+	return crypto.VerifySignature(pkData, hash, rs)
+}
+
+func verifyEDSignature(pkData, msg []byte, rsv string) bool {
+	sig := common.FromHex(rsv)
+	if len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(pkData), msg, sig)
+}
@@ -3,6 +3,7 @@ package tools
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
@@ -187,6 +188,30 @@ func GetP2shBindAddress(p2shAddress string) (bindAddress string) {
 	return ""
 }
 
+// GetDepositBindAddress get bind address of registered deposit address
+func GetDepositBindAddress(pairID, depositAddress string) (bindAddress string) {
+	depositAddress = strings.ToLower(depositAddress)
+	if mongodb.HasClient() {
+		result, _ := mongodb.FindDepositAddressInfo(depositAddress)
+		if result != nil && strings.EqualFold(result.PairID, pairID) {
+			return result.BindAddress
+		}
+		return ""
+	}
+	var result tokens.DepositAddressInfo
+	for i := 0; i < retryRPCCount; i++ {
+		err := client.RPCPostWithTimeout(swapRPCTimeout, &result, params.ServerAPIAddress, "swap.GetDepositAddressInfo", depositAddress)
+		if err == nil {
+			if strings.EqualFold(result.PairID, pairID) {
+				return result.BindAddress
+			}
+			return ""
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	return ""
+}
+
 // GetLatestScanHeight get latest scanned block height
 func GetLatestScanHeight(isSrc bool) uint64 {
 	if mongodb.HasClient() {
@@ -3,6 +3,11 @@ package tokens
 import (
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/types"
 )
 
 // SwapType type
@@ -62,6 +67,49 @@ type TxSwapInfo struct {
 	To        string   `json:"to"`
 	Bind      string   `json:"bind"`
 	Value     *big.Int `json:"value"`
+	LogIndex  int      `json:"logIndex,omitempty"` // of batched deposit, see `GetSwapID`
+
+	Call *SwapCall `json:"call,omitempty"`
+}
+
+// GetSwapID get swap ID of this swap
+func (s *TxSwapInfo) GetSwapID() string {
+	return GetSwapID(s.Hash, s.LogIndex)
+}
+
+// GetSwapID get swap ID of deposit in tx.
+// swap ID is tx hash of the first deposit in tx (log index is 0),
+// and `txhash:logIndex` of the other deposits in the same tx
+// (eg. batched or multicall deposits), where log index is
+// the block level index of deposit log (as shown by explorers).
+func GetSwapID(txHash string, logIndex int) string {
+	if logIndex == 0 {
+		return txHash
+	}
+	return fmt.Sprintf("%v:%v", txHash, logIndex)
+}
+
+// ParseSwapID parse swap ID to tx hash and log index
+func ParseSwapID(swapID string) (txHash string, logIndex int, err error) {
+	parts := strings.Split(swapID, ":")
+	switch len(parts) {
+	case 1:
+		return swapID, 0, nil
+	case 2:
+		logIndex, err = strconv.Atoi(parts[1])
+		if err != nil || logIndex <= 0 {
+			return "", 0, ErrWrongSwapID
+		}
+		return parts[0], logIndex, nil
+	default:
+		return "", 0, ErrWrongSwapID
+	}
+}
+
+// SwapCall call target contract with calldata when minting (swap-and-call)
+type SwapCall struct {
+	Target string        `json:"target"`
+	Data   hexutil.Bytes `json:"data"`
 }
 
 // TxStatus struct
@@ -98,10 +146,13 @@ type BuildTxArgs struct {
 	OriginTxTo  string     `json:"originTxTo,omitempty"`
 	Value       *big.Int   `json:"value,omitempty"`
 	OriginValue *big.Int   `json:"originValue,omitempty"`
+	OriginCall  *SwapCall  `json:"originCall,omitempty"`
 	SwapValue   *big.Int   `json:"swapvalue,omitempty"`
 	Memo        string     `json:"memo,omitempty"`
 	Input       *[]byte    `json:"input,omitempty"`
 	Extra       *AllExtras `json:"extra,omitempty"`
+
+	Batch []*BuildTxArgs `json:"batch,omitempty"` // extra args of batch sign entries
 }
 
 // GetReplaceNum get rplace swap count
@@ -154,6 +205,8 @@ type EthExtraArgs struct {
 	GasTipCap *big.Int `json:"gasTipCap,omitempty"`
 	GasFeeCap *big.Int `json:"gasFeeCap,omitempty"`
 	Nonce     *uint64  `json:"nonce,omitempty"`
+
+	AccessList types.AccessList `json:"accessList,omitempty"`
 }
 
 // RippleExtra struct
@@ -182,3 +235,90 @@ type P2shAddressInfo struct {
 	RedeemScript       string
 	RedeemScriptDisasm string
 }
+
+// UtxoInfo utxo statistics of btc like bridge address
+type UtxoInfo struct {
+	Address           string
+	RelayFeePerKb     int64
+	DustThreshold     uint64
+	SwapoutFee        uint64
+	UtxoCount         int
+	TotalValue        uint64
+	UnconfirmedCount  int
+	UnconfirmedValue  uint64
+	PendingSpendCount int
+	PendingSpendValue uint64
+	DustCount         int
+	DustValue         uint64
+	ProjectedSwapouts int // count of spendable utxos, each can fund one swapout in parallel
+	SpendableValue    uint64
+
+	// projection after sending pending (not sent yet) swapouts
+	PendingSwapoutCount int
+	PendingSwapoutValue uint64
+	ProjectedBalance    int64 // spendable and unconfirmed value minus pending swapouts value and fees
+	ProjectedUtxoCount  int   // spendable utxos left after funding pending swapouts
+
+	Histogram       []*UtxoHistogramItem
+	P2shUtxoCount   int
+	P2shTotalValue  uint64
+	ShouldAggregate bool
+	P2shBalances    []*P2shBalance `json:",omitempty"` // top balances only
+	UpdateTime      int64
+}
+
+// UtxoHistogramItem utxo count and value in range [MinValue, MaxValue)
+type UtxoHistogramItem struct {
+	MinValue uint64
+	MaxValue uint64 `json:",omitempty"` // zero means no upper bound
+	Count    int
+	Value    uint64
+}
+
+// P2shBalance p2sh address balance
+type P2shBalance struct {
+	BindAddress string
+	P2shAddress string
+	UtxoCount   int
+	Value       uint64
+}
+
+// DepositAddressInfo struct
+type DepositAddressInfo struct {
+	PairID         string
+	BindAddress    string
+	DepositAddress string
+	Salt           string
+	Factory        string
+}
+
+// SignedSwapoutArgs struct of relayed swapout.
+// owner signs swapout intent off-chain (permit of mapping token),
+// and relayer sends `SwapoutWithPermit` tx and pays the gas.
+type SignedSwapoutArgs struct {
+	PairID    string `json:"pairid"`
+	Owner     string `json:"owner"`
+	Bind      string `json:"bind"`
+	Amount    string `json:"amount"`
+	Deadline  uint64 `json:"deadline"`
+	Signature string `json:"signature"` // 65 bytes rsv
+}
+
+// mapping token event types
+const (
+	MappingTokenMint       = "mint"
+	MappingTokenBurn       = "burn"
+	MappingTokenRoleChange = "rolechange"
+)
+
+// MappingTokenEvent mint, burn or role change event of mapping token contract
+type MappingTokenEvent struct {
+	Contract    string
+	EventType   string
+	EventName   string // eg. Transfer, OwnershipTransferred, RoleGranted
+	TxHash      string
+	BlockHeight uint64
+	LogIndex    uint
+	Account     string   // receiver of mint, sender of burn, or new owner/minter
+	Value       *big.Int // value of mint or burn
+}
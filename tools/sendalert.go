@@ -0,0 +1,26 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+)
+
+const alertPostTimeout = 10 // seconds
+
+// PostAlert post alert message in json to webhook (do nothing if webhook is empty)
+func PostAlert(webhook, subject string, content map[string]interface{}) error {
+	if webhook == "" {
+		return nil
+	}
+	body := map[string]interface{}{
+		"subject":   subject,
+		"content":   content,
+		"timestamp": time.Now().Unix(),
+	}
+	resp, err := client.HTTPPost(webhook, body, nil, nil, alertPostTimeout)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -41,6 +41,8 @@ var (
 	errIdentifierMismatch = errors.New("cross chain bridge identifier mismatch")
 	errInitiatorMismatch  = errors.New("initiator mismatch")
 	errWrongMsgContext    = errors.New("wrong msg context")
+
+	errWrongBatchSign = errors.New("wrong batch sign")
 )
 
 // StartAcceptSignJob accept job
@@ -60,8 +62,15 @@ func StartAcceptSignJob() {
 	acceptSignStarter.Do(func() {
 		logWorker("accept", "start accept sign job")
 		openLeveldb()
+		loadOutflowRecords()
 		go startAcceptProducer()
 
+		retentionBlocks := params.GetOracleConfig().AcceptRecordRetentionBlocks
+		if lvldbHandle != nil && retentionBlocks > 0 {
+			acceptRetentionWaitGroup.Add(1)
+			go startAcceptRetentionJob(retentionBlocks)
+		}
+
 		utils.TopWaitGroup.Add(1)
 		go startAcceptConsumer()
 	})
@@ -104,6 +113,7 @@ func startAcceptProducer() {
 
 func startAcceptConsumer() {
 	defer func() {
+		acceptRetentionWaitGroup.Wait()
 		closeLeveldb()
 		utils.TopWaitGroup.Done()
 	}()
@@ -166,6 +176,9 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 			"swapID", args.SwapID,
 			"bind", args.Bind,
 		)
+		if len(args.Batch) > 0 {
+			ctx = append(ctx, "batch", len(args.Batch))
+		}
 	}
 
 	switch {
@@ -198,12 +211,13 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 	}
 
 	var aggreeMsgContext []string
+	var disgreeReason string
 	agreeResult := acceptAgree
 	if err != nil {
 		logWorkerError("accept", "DISAGREE sign", err, ctx...)
 		agreeResult = acceptDisagree
 
-		disgreeReason := err.Error()
+		disgreeReason = err.Error()
 		if len(disgreeReason) > 1000 {
 			disgreeReason = disgreeReason[:1000]
 		}
@@ -219,6 +233,9 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 	} else {
 		logWorker("accept", "accept sign job finish", ctx...)
 		isProcessed = true
+		if agreeResult == acceptDisagree {
+			go reportSignDisagree(keyID, disgreeReason)
+		}
 	}
 }
 
@@ -242,7 +259,10 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 	switch args.Identifier {
 	case params.GetIdentifier():
 	case params.GetReplaceIdentifier():
+	case params.GetBatchIdentifier():
 	case tokens.AggregateIdentifier:
+	case tokens.SweepIdentifier:
+	case tokens.FillNonceIdentifier:
 	default:
 		return args, errIdentifierMismatch
 	}
@@ -262,21 +282,137 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 		return args, nil
 	}
 
-	logWorker("accept", "verifySignInfo", "keyID", signInfo.Key, "msgHash", msgHash, "msgContext", msgContext)
-	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
-		err = CheckAcceptRecord(args)
+	if args.Identifier == tokens.SweepIdentifier {
+		sweeper, ok := tokens.SrcBridge.(interface {
+			VerifySweepMsgHash(msgHash []string, args *tokens.BuildTxArgs) error
+		})
+		if !ok {
+			return args, errIdentifierMismatch
+		}
+		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		err = sweeper.VerifySweepMsgHash(msgHash, args)
+		if err != nil {
+			return args, err
+		}
+		return args, nil
+	}
+
+	if args.Identifier == tokens.FillNonceIdentifier {
+		filler, ok := tokens.GetCrossChainBridge(!args.IsSwapin()).(interface {
+			VerifyFillNonceMsgHash(msgHash []string, args *tokens.BuildTxArgs) error
+		})
+		if !ok {
+			return args, errIdentifierMismatch
+		}
+		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		err = filler.VerifyFillNonceMsgHash(msgHash, args)
 		if err != nil {
 			return args, err
 		}
+		return args, nil
+	}
+
+	logWorker("accept", "verifySignInfo", "keyID", signInfo.Key, "msgHash", msgHash, "msgContext", msgContext)
+	if args.Identifier == params.GetBatchIdentifier() {
+		err = verifyBatchSignInfo(signInfo.Key, msgHash, args)
+	} else {
+		err = verifySwapSignInfo(signInfo.Key, msgHash, args)
 	}
-	err = rebuildAndVerifyMsgHash(signInfo.Key, msgHash, args)
 	if err != nil {
 		return args, err
 	}
 	return args, nil
 }
 
-func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) error {
+func verifySwapSignInfo(keyID string, msgHash []string, args *tokens.BuildTxArgs) error {
+	record, err := checkAndVerifySwapSignInfo(keyID, msgHash, args)
+	if err != nil {
+		return err
+	}
+	if record.isNeedSave() {
+		go record.save()
+	}
+	return nil
+}
+
+func checkAndVerifySwapSignInfo(keyID string, msgHash []string, args *tokens.BuildTxArgs) (*acceptRecord, error) {
+	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
+		err := CheckAcceptRecord(args)
+		if err != nil {
+			return nil, err
+		}
+		if params.GetIndependentVerifyConfig() != nil {
+			err = CheckAcceptNonceRecord(args)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rebuildAndVerifyMsgHash(keyID, msgHash, args)
+}
+
+// verifyBatchSignInfo verify every swap of batch sign,
+// require swaps have the same swap type, consecutive nonces and no duplicate.
+// the batch is accepted only if all its swaps are verified,
+// and the accept nonce records are saved before agreeing.
+func verifyBatchSignInfo(keyID string, msgHash []string, args *tokens.BuildTxArgs) (err error) {
+	count := len(args.Batch)
+	if count == 0 || count > params.MaxBatchSignCount || count != len(msgHash) {
+		return fmt.Errorf("%w: swaps count %v, msg hash count %v", errWrongBatchSign, count, len(msgHash))
+	}
+	first := args.Batch[0]
+	swapKeys := make(map[string]struct{}, count)
+	for i, swapArgs := range args.Batch {
+		if swapArgs == nil || swapArgs.Identifier != params.GetIdentifier() {
+			return fmt.Errorf("%w: swap %v has wrong identifier", errWrongBatchSign, i)
+		}
+		if swapArgs.SwapType != first.SwapType {
+			return fmt.Errorf("%w: swap %v has different swap type", errWrongBatchSign, i)
+		}
+		if swapArgs.Extra == nil || swapArgs.Extra.EthExtra == nil || swapArgs.Extra.EthExtra.Nonce == nil ||
+			swapArgs.GetTxNonce() != first.GetTxNonce()+uint64(i) {
+			return fmt.Errorf("%w: swap %v has not consecutive nonce", errWrongBatchSign, i)
+		}
+		swapKey := getSwapKeyPrefix(swapArgs)
+		if _, exist := swapKeys[swapKey]; exist {
+			return fmt.Errorf("%w: swap %v is duplicated", errWrongBatchSign, i)
+		}
+		swapKeys[swapKey] = struct{}{}
+	}
+
+	records := make([]*acceptRecord, 0, count)
+	defer func() {
+		if err != nil {
+			for _, record := range records {
+				record.rollback()
+			}
+		}
+	}()
+	for i, swapArgs := range args.Batch {
+		record, errv := checkAndVerifySwapSignInfo(keyID, msgHash[i:i+1], swapArgs)
+		if errv != nil {
+			return fmt.Errorf("batch swap %v (%v) verify failed: %w", i, swapArgs.SwapID, errv)
+		}
+		records = append(records, record)
+	}
+	for i, record := range records {
+		if !record.isNeedSave() {
+			continue
+		}
+		err = record.saveNonce()
+		if err != nil {
+			return fmt.Errorf("batch swap %v (%v) save accept record failed: %w", i, record.args.SwapID, err)
+		}
+	}
+	for _, record := range records {
+		if record.isNeedSave() {
+			go record.save()
+		}
+	}
+	return nil
+}
+
+func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) (record *acceptRecord, err error) {
 	var srcBridge, dstBridge tokens.CrossChainBridge
 	switch args.SwapType {
 	case tokens.SwapinType:
@@ -286,12 +422,12 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		srcBridge = tokens.DstBridge
 		dstBridge = tokens.SrcBridge
 	default:
-		return fmt.Errorf("unknown swap type %v", args.SwapType)
+		return nil, fmt.Errorf("unknown swap type %v", args.SwapType)
 	}
 
 	tokenCfg := dstBridge.GetTokenConfig(args.PairID)
 	if tokenCfg == nil {
-		return tokens.ErrUnknownPairID
+		return nil, tokens.ErrUnknownPairID
 	}
 
 	ctx := []interface{}{
@@ -306,32 +442,106 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	swapInfo, err := verifySwapTransaction(srcBridge, args.PairID, args.SwapID, args.Bind, args.TxType)
 	if err != nil {
 		logWorkerError("accept", "verifySignInfo failed", err, ctx...)
-		return err
+		return nil, err
+	}
+
+	err = verifyByIndependentGateways(srcBridge, swapInfo)
+	if err != nil {
+		logWorkerError("accept", "verify by independent gateways failed", err, ctx...)
+		return nil, err
+	}
+
+	err = checkPolicyRules(srcBridge, args, swapInfo)
+	if err != nil {
+		logWorkerError("accept", "check policy rules failed", err, ctx...)
+		return nil, err
 	}
 
+	releaseOutflow, err := reserveOutflow(srcBridge, args, swapInfo)
+	if err != nil {
+		logWorkerError("accept", "check outflow cap failed", err, ctx...)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			releaseOutflow()
+		}
+	}()
+
 	buildTxArgs := &tokens.BuildTxArgs{
 		SwapInfo:    args.SwapInfo,
 		From:        tokenCfg.DcrmAddress,
 		OriginFrom:  swapInfo.From,
 		OriginTxTo:  swapInfo.TxTo,
 		OriginValue: swapInfo.Value,
+		OriginCall:  swapInfo.Call,
 		Extra:       args.Extra,
 	}
 	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
 	if err != nil {
 		logWorkerError("accept", "build raw tx failed", err, ctx...)
-		return err
+		return nil, err
 	}
 	err = dstBridge.VerifyMsgHash(rawTx, msgHash)
 	if err != nil {
 		logWorkerError("accept", "verify message hash failed", err, ctx...)
-		return err
+		return nil, err
 	}
-	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
-		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx)
+	if gasVerifier, ok := dstBridge.(interface {
+		VerifySwapTxGas(rawTx interface{}, args *tokens.BuildTxArgs) error
+	}); ok {
+		err = gasVerifier.VerifySwapTxGas(rawTx, buildTxArgs)
+		if err != nil {
+			logWorkerError("accept", "verify swap tx gas failed", err, ctx...)
+			return nil, err
+		}
 	}
 	logWorker("accept", "verify message hash success", ctx...)
-	return nil
+	return &acceptRecord{
+		bridge:         dstBridge,
+		keyID:          keyID,
+		args:           buildTxArgs,
+		rawTx:          rawTx,
+		releaseOutflow: releaseOutflow,
+	}, nil
+}
+
+// acceptRecord is a verified swap to be recorded as accepted
+type acceptRecord struct {
+	bridge         tokens.CrossChainBridge
+	keyID          string
+	args           *tokens.BuildTxArgs
+	rawTx          interface{}
+	releaseOutflow func()
+	nonceSaved     bool
+}
+
+func (r *acceptRecord) isNeedSave() bool {
+	return lvldbHandle != nil && r.args.GetTxNonce() > 0 // only for eth like chain
+}
+
+func (r *acceptRecord) saveNonce() error {
+	err := AddAcceptNonceRecord(r.args)
+	if err == nil {
+		r.nonceSaved = true
+	}
+	return err
+}
+
+// rollback release reserved outflow and delete saved accept nonce record
+func (r *acceptRecord) rollback() {
+	r.releaseOutflow()
+	if r.nonceSaved {
+		err := DeleteAcceptNonceRecord(r.args)
+		if err != nil {
+			logWorkerError("accept", "delete accept nonce record failed", err, "swapID", r.args.SwapID, "nonce", r.args.GetTxNonce())
+		}
+	}
+}
+
+// save save accept record after the swap tx is signed
+func (r *acceptRecord) save() {
+	saveAcceptRecord(r.bridge, r.keyID, r.args, r.rawTx)
 }
 
 func saveAcceptRecord(bridge tokens.CrossChainBridge, keyID string, args *tokens.BuildTxArgs, rawTx interface{}) {
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -13,13 +14,17 @@ import (
 )
 
 const (
-	identifierKey = "bridge-identifier"
+	identifierKey  = "bridge-identifier"
+	nonceKeyPrefix = "swapnonce:"
+	doneKeyPrefix  = "swapdone:"
 
 	allowReswapTimeInterval = 1800 // seconds
 )
 
 var (
 	lvldbHandle *leveldb.Database
+
+	errAcceptedWithOtherNonce = errors.New("swap is already accepted with other nonce")
 )
 
 func getSwapKeyPrefix(args *tokens.BuildTxArgs) string {
@@ -42,7 +47,61 @@ func AddAcceptRecord(args *tokens.BuildTxArgs, swapTx string) (err error) {
 		return nil
 	}
 	key := []byte(getSwapKeyPrefix(args) + swapTx)
-	return lvldbHandle.Put(key, int64ToBytes(now()))
+	err = lvldbHandle.Put(key, int64ToBytes(now()))
+	if err != nil {
+		return err
+	}
+	return AddAcceptNonceRecord(args)
+}
+
+func getAcceptNonceKey(args *tokens.BuildTxArgs) []byte {
+	return []byte(fmt.Sprintf("%s%s%d", nonceKeyPrefix, getSwapKeyPrefix(args), args.GetTxNonce()))
+}
+
+// AddAcceptNonceRecord add accept nonce record
+// (it can be added before signing as it does not need the signed tx hash)
+func AddAcceptNonceRecord(args *tokens.BuildTxArgs) (err error) {
+	if lvldbHandle == nil {
+		return nil
+	}
+	return lvldbHandle.Put(getAcceptNonceKey(args), int64ToBytes(now()))
+}
+
+// DeleteAcceptNonceRecord delete accept nonce record
+func DeleteAcceptNonceRecord(args *tokens.BuildTxArgs) (err error) {
+	if lvldbHandle == nil {
+		return nil
+	}
+	return lvldbHandle.Delete(getAcceptNonceKey(args))
+}
+
+// CheckAcceptNonceRecord check the swap is not accepted with other nonce
+// (reswapping is allowed if the old accept is old enough)
+func CheckAcceptNonceRecord(args *tokens.BuildTxArgs) (err error) {
+	if lvldbHandle == nil {
+		return nil
+	}
+	nowTime := now()
+	argNonce := fmt.Sprintf("%d", args.GetTxNonce())
+
+	prefix := []byte(nonceKeyPrefix + getSwapKeyPrefix(args))
+	prefixLen := len(prefix)
+	iter := lvldbHandle.NewIterator(prefix, nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := string(iter.Key())
+		value := bytesToInt64(iter.Value())
+		nonce := key[prefixLen:]
+		if nonce == argNonce {
+			continue
+		}
+		if args.Reswapping && value+allowReswapTimeInterval <= nowTime {
+			continue
+		}
+		log.Warn("[accept] found accept record with other nonce", "key", key, "value", value, "argNonce", argNonce)
+		return errAcceptedWithOtherNonce
+	}
+	return nil
 }
 
 // FindAcceptRecords find accept records
@@ -60,11 +119,30 @@ func FindAcceptRecords(args *tokens.BuildTxArgs) map[string]int64 {
 	return result
 }
 
+// isSwapTxStatusOk is the on chain swap tx succeeded
+// (only eth like chain has receipt, and may be failed)
+func isSwapTxStatusOk(txStatus *tokens.TxStatus) bool {
+	if txStatus.Receipt == nil {
+		return true
+	}
+	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	return ok && receipt.IsStatusOk()
+}
+
+func getSwapDoneKey(args *tokens.BuildTxArgs) string {
+	return doneKeyPrefix + getSwapKeyPrefix(args)
+}
+
 // CheckAcceptRecord check accept record
 func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 	if lvldbHandle == nil {
 		return nil
 	}
+	// accept records of done swap are pruned, but its done marker is kept
+	if isDone, errh := lvldbHandle.Has([]byte(getSwapDoneKey(args))); errh == nil && isDone {
+		log.Warn("[accept] found done marker of swap", "key", getSwapDoneKey(args))
+		return errAlreadySwapped
+	}
 	isSwapin := args.SwapType == tokens.SwapinType
 	resBridge := tokens.GetCrossChainBridge(!isSwapin)
 	alreadySwapped := false
@@ -80,14 +158,7 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 		log.Info("[accept] check saved record", "key", key, "value", value)
 		txStatus, errt := resBridge.GetTransactionStatus(oldSwapTx)
 		if errt == nil && txStatus != nil && txStatus.BlockHeight > 0 { // on chain
-			if txStatus.Receipt != nil { // for eth like chain
-				receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
-				if ok && receipt.IsStatusOk() {
-					log.Warn("[accept] found already swapped tx", "key", key, "value", value)
-					alreadySwapped = true
-					break
-				}
-			} else {
+			if isSwapTxStatusOk(txStatus) {
 				log.Warn("[accept] found already swapped tx", "key", key, "value", value)
 				alreadySwapped = true
 				break
@@ -0,0 +1,291 @@
+package worker
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// accept record formats of exporting and importing
+const (
+	AcceptRecordJSONFormat = "json"
+	AcceptRecordCSVFormat  = "csv"
+)
+
+var (
+	errAcceptDBNotOpened = errors.New("accept database is not opened")
+
+	acceptRecordCSVHeader = []string{"key", "timestamp", "swapid", "swaptype", "pairid", "bind", "swaptx", "nonce"}
+)
+
+// AcceptRecord accept record in accept database.
+// key is `swapid:swaptype:pairid:bind:swaptx` of agreed swap tx,
+// or `swapnonce:swapid:swaptype:pairid:bind:nonce` of agreed nonce,
+// or `swapdone:swapid:swaptype:pairid:bind:` of done swap (kept after pruning).
+// swapid may contain ':' (eg. `txhash:logIndex`), so key is parsed from the right.
+type AcceptRecord struct {
+	Key       string `json:"key"`
+	Timestamp int64  `json:"timestamp"`
+
+	SwapID   string `json:"swapid,omitempty"`
+	SwapType string `json:"swaptype,omitempty"`
+	PairID   string `json:"pairid,omitempty"`
+	Bind     string `json:"bind,omitempty"`
+	SwapTx   string `json:"swaptx,omitempty"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+// IsNonceRecord is nonce record
+func (r *AcceptRecord) IsNonceRecord() bool {
+	return strings.HasPrefix(r.Key, nonceKeyPrefix)
+}
+
+// IsDoneRecord is done marker record
+func (r *AcceptRecord) IsDoneRecord() bool {
+	return strings.HasPrefix(r.Key, doneKeyPrefix)
+}
+
+func parseAcceptRecord(key []byte, value []byte) *AcceptRecord {
+	record := &AcceptRecord{Key: string(key)}
+	if len(value) == 8 {
+		record.Timestamp = bytesToInt64(value)
+	}
+	isNonceRecord := record.IsNonceRecord()
+	isDoneRecord := record.IsDoneRecord()
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(record.Key, nonceKeyPrefix), doneKeyPrefix), ":")
+	n := len(parts)
+	if n < 5 {
+		return record
+	}
+	record.SwapID = strings.Join(parts[:n-4], ":")
+	record.SwapType = parts[n-4]
+	if swapType, err := strconv.ParseUint(parts[n-4], 10, 32); err == nil {
+		record.SwapType = tokens.SwapType(swapType).String()
+	}
+	record.PairID = parts[n-3]
+	record.Bind = parts[n-2]
+	switch {
+	case isNonceRecord:
+		record.Nonce = parts[n-1]
+	case !isDoneRecord:
+		record.SwapTx = parts[n-1]
+	}
+	return record
+}
+
+// OpenAcceptDB open accept database (for command line tools)
+func OpenAcceptDB() {
+	openLeveldb()
+}
+
+// CloseAcceptDB close accept database (for command line tools)
+func CloseAcceptDB() {
+	closeLeveldb()
+	lvldbHandle = nil
+}
+
+// IterateAcceptRecords iterate accept records which key has prefix,
+// stop iterating if callback returns false.
+func IterateAcceptRecords(prefix string, callback func(*AcceptRecord) bool) error {
+	if lvldbHandle == nil {
+		return errAcceptDBNotOpened
+	}
+	iter := lvldbHandle.NewIterator([]byte(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if string(iter.Key()) == identifierKey || strings.HasPrefix(string(iter.Key()), outflowKeyPrefix) {
+			continue
+		}
+		if !callback(parseAcceptRecord(iter.Key(), iter.Value())) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// FindAcceptRecordsOfSwap find accept records (including nonce and done records) of swap
+func FindAcceptRecordsOfSwap(txid, pairID, bind string) (records []*AcceptRecord, err error) {
+	txid = strings.ToLower(txid)
+	pairID = strings.ToLower(pairID)
+	bind = strings.ToLower(bind)
+	callback := func(record *AcceptRecord) bool {
+		if record.SwapID == txid &&
+			(pairID == "" || record.PairID == pairID) &&
+			(bind == "" || record.Bind == bind) {
+			records = append(records, record)
+		}
+		return true
+	}
+	for _, prefix := range []string{txid + ":", nonceKeyPrefix + txid + ":", doneKeyPrefix + txid + ":"} {
+		err = IterateAcceptRecords(prefix, callback)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// PruneAcceptRecords delete accept records older than timestamp.
+// done markers are kept, and no done marker is added as the swap tx is not checked on chain.
+func PruneAcceptRecords(beforeTimestamp int64) (count int, err error) {
+	var keys []string
+	err = IterateAcceptRecords("", func(record *AcceptRecord) bool {
+		if record.IsDoneRecord() || record.Timestamp >= beforeTimestamp {
+			return true
+		}
+		keys = append(keys, record.Key)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleteAcceptRecords(keys, nil)
+}
+
+// deleteAcceptRecords delete records and add done markers in one batch
+func deleteAcceptRecords(keys []string, doneKeys map[string]struct{}) (count int, err error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	batch := lvldbHandle.NewBatch()
+	nowTime := now()
+	for doneKey := range doneKeys {
+		err = batch.Put([]byte(doneKey), int64ToBytes(nowTime))
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, key := range keys {
+		err = batch.Delete([]byte(key))
+		if err != nil {
+			return 0, err
+		}
+	}
+	err = batch.Write()
+	if err != nil {
+		return 0, err
+	}
+	err = lvldbHandle.Compact(nil, nil)
+	if err != nil {
+		log.Warn("compact accept database failed", "err", err)
+	}
+	return len(keys), nil
+}
+
+// ExportAcceptRecords export all accept records in json or csv format
+func ExportAcceptRecords(w io.Writer, format string) (count int, err error) {
+	var records []*AcceptRecord
+	err = IterateAcceptRecords("", func(record *AcceptRecord) bool {
+		records = append(records, record)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	switch format {
+	case AcceptRecordJSONFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(records)
+	case AcceptRecordCSVFormat:
+		writer := csv.NewWriter(w)
+		_ = writer.Write(acceptRecordCSVHeader)
+		for _, r := range records {
+			_ = writer.Write([]string{r.Key, strconv.FormatInt(r.Timestamp, 10), r.SwapID, r.SwapType, r.PairID, r.Bind, r.SwapTx, r.Nonce})
+		}
+		writer.Flush()
+		err = writer.Error()
+	default:
+		return 0, fmt.Errorf("unknown format '%v'", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// ImportAcceptRecords import accept records exported by ExportAcceptRecords
+func ImportAcceptRecords(r io.Reader, format string) (count int, err error) {
+	if lvldbHandle == nil {
+		return 0, errAcceptDBNotOpened
+	}
+	var records []*AcceptRecord
+	switch format {
+	case AcceptRecordJSONFormat:
+		err = json.NewDecoder(r).Decode(&records)
+	case AcceptRecordCSVFormat:
+		records, err = readAcceptRecordsCSV(r)
+	default:
+		return 0, fmt.Errorf("unknown format '%v'", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	batch := lvldbHandle.NewBatch()
+	for _, record := range records {
+		if !isImportableAcceptKey(record.Key) {
+			return 0, fmt.Errorf("can not import accept record with key '%v'", record.Key)
+		}
+		err = batch.Put([]byte(record.Key), int64ToBytes(record.Timestamp))
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+	err = batch.Write()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// isImportableAcceptKey only swap tx records and nonce records can be imported,
+// done markers and outflow records are maintained by the oracle itself.
+func isImportableAcceptKey(key string) bool {
+	if strings.HasPrefix(key, doneKeyPrefix) || strings.HasPrefix(key, outflowKeyPrefix) {
+		return false
+	}
+	isNonceRecord := strings.HasPrefix(key, nonceKeyPrefix)
+	parts := strings.Split(strings.TrimPrefix(key, nonceKeyPrefix), ":")
+	n := len(parts)
+	if n < 5 || parts[0] == "" || parts[n-3] == "" || parts[n-2] == "" || parts[n-1] == "" {
+		return false
+	}
+	if _, err := strconv.ParseUint(parts[n-4], 10, 32); err != nil {
+		return false
+	}
+	if isNonceRecord {
+		if _, err := strconv.ParseUint(parts[n-1], 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func readAcceptRecordsCSV(r io.Reader) (records []*AcceptRecord, err error) {
+	lines, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		if i == 0 && len(line) > 0 && line[0] == acceptRecordCSVHeader[0] {
+			continue // skip header
+		}
+		if len(line) < 2 {
+			return nil, fmt.Errorf("wrong csv line %v", i+1)
+		}
+		timestamp, err := strconv.ParseInt(line[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("wrong timestamp in csv line %v: %w", i+1, err)
+		}
+		records = append(records, &AcceptRecord{Key: line[0], Timestamp: timestamp})
+	}
+	return records, nil
+}
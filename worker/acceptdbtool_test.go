@@ -0,0 +1,27 @@
+package worker
+
+import "testing"
+
+func TestIsImportableAcceptKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"0xabc:1:pairid:0xbind:0xswaptx", true},
+		{"0xabc:3:1:pairid:0xbind:0xswaptx", true}, // swapid with log index
+		{"swapnonce:0xabc:1:pairid:0xbind:5", true},
+		{"swapnonce:0xabc:1:pairid:0xbind:five", false},
+		{"swapdone:0xabc:1:pairid:0xbind:", false},
+		{"outflow:0xabc:1:pairid:0xbind:", false},
+		{"bridge-identifier", false},
+		{"0xabc:swapin:pairid:0xbind:0xswaptx", false},
+		{"0xabc:1:pairid:0xbind:", false},
+		{"a:b:c", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if got := isImportableAcceptKey(test.key); got != test.want {
+			t.Errorf("key '%v': want %v, got %v", test.key, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const acceptRetentionInterval = time.Hour
+
+var acceptRetentionWaitGroup sync.WaitGroup
+
+// startAcceptRetentionJob prune accept records of swaps
+// whose swap tx is succeeded and stable beyond retention blocks,
+// only the done markers of these swaps are kept.
+func startAcceptRetentionJob(retentionBlocks uint64) {
+	logWorker("acceptretention", "start accept retention job", "retentionBlocks", retentionBlocks)
+	defer acceptRetentionWaitGroup.Done()
+	for {
+		pruneStableAcceptRecords(retentionBlocks)
+		select {
+		case <-utils.CleanupChan:
+			logWorker("acceptretention", "stop accept retention job")
+			return
+		case <-time.After(acceptRetentionInterval):
+		}
+	}
+}
+
+func pruneStableAcceptRecords(retentionBlocks uint64) {
+	stableSwaps := make(map[string]struct{}) // key is swap key prefix
+	err := IterateAcceptRecords("", func(record *AcceptRecord) bool {
+		if utils.IsCleanuping() {
+			return false
+		}
+		if record.IsNonceRecord() || record.IsDoneRecord() || record.SwapTx == "" {
+			return true
+		}
+		swapPrefix := strings.TrimSuffix(record.Key, record.SwapTx)
+		if _, exist := stableSwaps[swapPrefix]; exist {
+			return true
+		}
+		isSwapin := record.SwapType == tokens.SwapinType.String()
+		resBridge := tokens.GetCrossChainBridge(!isSwapin)
+		txStatus, errt := resBridge.GetTransactionStatus(record.SwapTx)
+		if errt == nil && txStatus != nil && txStatus.BlockHeight > 0 &&
+			txStatus.Confirmations >= retentionBlocks && isSwapTxStatusOk(txStatus) {
+			stableSwaps[swapPrefix] = struct{}{}
+		}
+		return true
+	})
+	if err != nil {
+		logWorkerError("acceptretention", "iterate accept records failed", err)
+		return
+	}
+	if len(stableSwaps) == 0 || utils.IsCleanuping() {
+		return
+	}
+	var keys []string
+	doneKeys := make(map[string]struct{}, len(stableSwaps))
+	for swapPrefix := range stableSwaps {
+		doneKeys[doneKeyPrefix+swapPrefix] = struct{}{}
+		for _, prefix := range []string{swapPrefix, nonceKeyPrefix + swapPrefix} {
+			_ = IterateAcceptRecords(prefix, func(record *AcceptRecord) bool {
+				keys = append(keys, record.Key)
+				return true
+			})
+		}
+	}
+	count, err := deleteAcceptRecords(keys, doneKeys)
+	if err != nil {
+		logWorkerError("acceptretention", "prune accept records failed", err, "swaps", len(stableSwaps))
+		return
+	}
+	logWorker("acceptretention", "prune accept records success", "swaps", len(stableSwaps), "records", count)
+}
@@ -17,6 +17,11 @@ var (
 	aggUtxos    []*electrs.ElectUtxo
 	aggOffset   int
 	aggInterval = 10 * time.Minute
+
+	// aggregate statistics since start
+	aggStatTxCount   uint64
+	aggStatUtxoCount uint64
+	aggStatSumValue  uint64
 )
 
 // StartAggregateJob aggregate job
@@ -37,12 +42,22 @@ func loopDoAggregateJob() {
 		}
 		logWorker("aggregate", "start aggregate job", "loop", loop)
 		doAggregateJob()
-		logWorker("aggregate", "finish aggregate job", "loop", loop)
+		logWorker("aggregate", "finish aggregate job", "loop", loop,
+			"totalTxs", aggStatTxCount, "totalUtxos", aggStatUtxoCount, "totalValue", aggStatSumValue)
 		time.Sleep(aggInterval)
 	}
 }
 
 func doAggregateJob() {
+	relayFeePerKb, isLowFee, err := btc.BridgeInstance.GetAggregateRelayFee()
+	if err != nil {
+		logWorkerError("aggregate", "GetAggregateRelayFee failed", err)
+		return
+	}
+	if !isLowFee {
+		logWorker("aggregate", "skip aggregate as relay fee is too high", "relayFeePerKb", relayFeePerKb)
+		return
+	}
 	aggOffset = 0
 	for {
 		if utils.IsCleanuping() {
@@ -55,7 +70,7 @@ func doAggregateJob() {
 			continue
 		}
 		for _, p2shAddr := range p2shAddrs {
-			findUtxosAndAggregate(p2shAddr.P2shAddress)
+			findUtxosAndAggregate(p2shAddr.P2shAddress, relayFeePerKb)
 		}
 		if len(p2shAddrs) < utxoPageLimit {
 			break
@@ -64,12 +79,16 @@ func doAggregateJob() {
 	}
 }
 
-func findUtxosAndAggregate(addr string) {
+func findUtxosAndAggregate(addr string, relayFeePerKb int64) {
 	findUtxos, _ := btc.BridgeInstance.FindUtxos(addr)
 	for _, utxo := range findUtxos {
 		if utxo.Value == nil || *utxo.Value == 0 {
 			continue
 		}
+		if btc.BridgeInstance.IsAggregateDust(*utxo.Value, relayFeePerKb) {
+			logWorkerTrace("aggregate", "ignore dust utxo", "address", addr, "utxo", utxo.String(), "relayFeePerKb", relayFeePerKb)
+			continue
+		}
 		if isUtxoExist(utxo) {
 			continue
 		}
@@ -109,7 +128,11 @@ func aggregate() {
 	if err != nil {
 		logWorkerError("aggregate", "AggregateUtxos failed", err)
 	} else {
-		logWorker("aggregate", "AggregateUtxos succeed", "txHash", txHash, "utxos", len(aggUtxos), "sumVal", aggSumVal)
+		aggStatTxCount++
+		aggStatUtxoCount += uint64(len(aggUtxos))
+		aggStatSumValue += aggSumVal
+		logWorker("aggregate", "AggregateUtxos succeed", "txHash", txHash, "utxos", len(aggUtxos), "sumVal", aggSumVal,
+			"totalTxs", aggStatTxCount, "totalUtxos", aggStatUtxoCount, "totalValue", aggStatSumValue)
 	}
 	aggSumVal = 0
 	aggAddrs = nil
@@ -0,0 +1,173 @@
+package worker
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// batchSigner sign several raw txs in one dcrm sign request
+type batchSigner interface {
+	DcrmSignTransactions(rawTxs []interface{}, batchArgs *tokens.BuildTxArgs) (signTxs []interface{}, txHashes []string, err error)
+}
+
+// getBatchSwapCount get max count of swaps processed together (batch sign or pre-sign)
+func getBatchSwapCount() int {
+	serverCfg := params.GetServerConfig()
+	if serverCfg == nil {
+		return 0
+	}
+	if serverCfg.PreSignCount > 1 {
+		return serverCfg.PreSignCount
+	}
+	return serverCfg.BatchSignCount
+}
+
+func isBatchSignable(resBridge tokens.CrossChainBridge, args *tokens.BuildTxArgs) bool {
+	if isPreSignEnabled() {
+		if _, ok := resBridge.(preSigner); !ok {
+			return false
+		}
+	} else if _, ok := resBridge.(batchSigner); !ok {
+		return false
+	}
+	if _, ok := resBridge.(tokens.NonceSetter); !ok {
+		return false
+	}
+	tokenCfg := resBridge.GetTokenConfig(args.PairID)
+	return tokenCfg != nil && tokenCfg.GetDcrmAddressPrivateKey() == nil
+}
+
+// collectBatchSwapTasks collect ready swap tasks from the channel without waiting,
+// return batch of tasks can be signed together and other tasks should be signed separately
+func collectBatchSwapTasks(swapChan <-chan *tokens.BuildTxArgs, first *tokens.BuildTxArgs, dcrmAddress string, isSwapin bool) (batch, others []*tokens.BuildTxArgs) {
+	batchSignCount := getBatchSwapCount()
+	resBridge := tokens.GetCrossChainBridge(!isSwapin)
+	if batchSignCount <= 1 || !isBatchSignable(resBridge, first) {
+		return []*tokens.BuildTxArgs{first}, nil
+	}
+	batch = append(batch, first)
+	for len(batch) < batchSignCount {
+		select {
+		case args := <-swapChan:
+			if !isSwapin && isSweepTask(args) {
+				others = append(others, args)
+				continue
+			}
+			if !strings.EqualFold(args.From, dcrmAddress) || args.SwapType != getSwapType(isSwapin) {
+				logWorkerWarn("doSwap", "ignore swap task as mismatch reason", "isSwapin", isSwapin, "dcrmAddress", dcrmAddress, "args", args)
+				continue
+			}
+			if isBatchSignable(resBridge, args) {
+				batch = append(batch, args)
+			} else {
+				others = append(others, args)
+			}
+		default:
+			return batch, others
+		}
+	}
+	return batch, others
+}
+
+// batchSwap swap task in batch sign
+type batchSwap struct {
+	args      *tokens.BuildTxArgs
+	cacheKey  string
+	rawTx     interface{}
+	processed bool
+}
+
+// doSwapBatch build txs with consecutive nonces, sign them in one dcrm sign request,
+// and then update database and send txs in nonce order.
+// swaps which are not sent are removed from cache to be retried later.
+func doSwapBatch(argsList []*tokens.BuildTxArgs) {
+	isSwapin := argsList[0].SwapType == tokens.SwapinType
+	resBridge := tokens.GetCrossChainBridge(!isSwapin)
+	signer := resBridge.(batchSigner)
+
+	swaps := make([]*batchSwap, 0, len(argsList))
+	defer func() {
+		for _, swap := range swaps {
+			if !swap.processed {
+				args := swap.args
+				logWorker("doSwapBatch", "delete swap cache", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin)
+				cachedSwapTasks.Remove(swap.cacheKey)
+			}
+		}
+	}()
+
+	var nextNonce uint64
+	for _, args := range argsList {
+		cacheKey := getSwapCacheKey(isSwapin, args.SwapID, args.PairID, args.Bind)
+		if checkAndUpdateProcessSwapTaskCache(cacheKey) != nil {
+			continue
+		}
+		if len(swaps) > 0 {
+			nonce := nextNonce
+			args.Extra = &tokens.AllExtras{EthExtra: &tokens.EthExtraArgs{Nonce: &nonce}}
+		}
+		rawTx, err := resBridge.BuildRawTransaction(args)
+		if err != nil {
+			logWorkerError("doSwapBatch", "build tx failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin)
+			markSwapTxWillRevert(args, err)
+			cachedSwapTasks.Remove(cacheKey)
+			break // keep nonces consecutive
+		}
+		if err = simulateSwapTx(resBridge, rawTx, args); err != nil {
+			cachedSwapTasks.Remove(cacheKey)
+			break // keep nonces consecutive
+		}
+		swaps = append(swaps, &batchSwap{args: args, cacheKey: cacheKey, rawTx: rawTx})
+		nextNonce = args.GetTxNonce() + 1
+	}
+	if len(swaps) == 0 {
+		return
+	}
+
+	rawTxs := make([]interface{}, len(swaps))
+	batchArgs := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{Identifier: params.GetBatchIdentifier()},
+		Batch:    make([]*tokens.BuildTxArgs, len(swaps)),
+	}
+	for i, swap := range swaps {
+		rawTxs[i] = swap.rawTx
+		batchArgs.Batch[i] = swap.args.GetExtraArgs()
+	}
+
+	logWorker("doSwapBatch", "start to process", "count", len(swaps), "isSwapin", isSwapin, "firstNonce", swaps[0].args.GetTxNonce())
+
+	var signedTxs []interface{}
+	var txHashes []string
+	var err error
+	for i := 1; i <= 3; i++ { // with retry
+		signedTxs, txHashes, err = signer.DcrmSignTransactions(rawTxs, batchArgs)
+		if err == nil {
+			break
+		}
+		logWorkerError("doSwapBatch", "sign txs failed", err, "count", len(swaps), "isSwapin", isSwapin, "signCount", i)
+		restInJob(retrySignInterval)
+	}
+	if err != nil {
+		if errors.Is(err, dcrm.ErrGetSignStatusHasDisagree) {
+			for _, swap := range swaps {
+				reverifySwap(swap.args)
+			}
+		}
+		return
+	}
+
+	for i, swap := range swaps {
+		args := swap.args
+		swap.processed, err = finishSwap(resBridge, args, signedTxs[i], txHashes[i])
+		if err != nil {
+			if !errors.Is(err, errAlreadySwapped) {
+				logWorkerError("doSwapBatch", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "swapNonce", args.GetTxNonce())
+			}
+			break // the following txs can not be sent with nonce hole
+		}
+	}
+}
@@ -29,7 +29,7 @@ func getSwapType(isSwapin bool) tokens.SwapType {
 }
 
 func addInitialSwapResult(swapInfo *tokens.TxSwapInfo, status mongodb.SwapStatus, isSwapin bool) (err error) {
-	txid := swapInfo.Hash
+	txid := swapInfo.GetSwapID()
 	var swapType tokens.SwapType
 	if isSwapin {
 		swapType = tokens.SwapinType
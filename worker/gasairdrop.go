@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	gasAirdropPageLimit int64 = 50
+
+	gasAirdropInterval = 30 * time.Second
+	gasAirdropPeriod   = int64(24 * 3600)
+)
+
+// gasAirdropper airdrop native gas to fresh bind address of swapin
+type gasAirdropper interface {
+	ShouldAirdropGas(pairID, bind string) (bool, error)
+	AirdropGas(pairID, bind string) (string, error)
+}
+
+// StartGasAirdropJob gas airdrop job
+func StartGasAirdropJob() {
+	airdropper, ok := tokens.DstBridge.(gasAirdropper)
+	if !ok {
+		return
+	}
+	if !hasGasAirdrop() {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopDoGasAirdropJob(airdropper)
+}
+
+func hasGasAirdrop() bool {
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		if pairCfg.DestToken.GasAirdrop != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getGasAirdropChain get chain of gas airdrop (dest chain of swapin)
+func getGasAirdropChain() string {
+	chainCfg := tokens.DstBridge.GetChainConfig()
+	return fmt.Sprintf("%v:%v", chainCfg.BlockChain, chainCfg.NetID)
+}
+
+// addGasAirdrop add pending gas airdrop of stable swapin if its swap fee can pay for the airdrop cost
+func addGasAirdrop(swap *mongodb.MgoSwapResult) {
+	pairCfg := tokens.GetTokenPairConfig(swap.PairID)
+	if pairCfg == nil || pairCfg.DestToken.GasAirdrop == nil {
+		return
+	}
+	srcToken, dstToken := pairCfg.SrcToken, pairCfg.DestToken
+	value, okValue := new(big.Int).SetString(swap.Value, 0)
+	swapValue, okSwapValue := new(big.Int).SetString(swap.SwapValue, 0)
+	if !okValue || !okSwapValue {
+		return
+	}
+	swapFee := tokens.ConvertTokenValue(value, *srcToken.Decimals, *dstToken.Decimals)
+	swapFee = new(big.Int).Sub(swapFee, swapValue)
+	if !dstToken.GasAirdrop.IsSwapFeeEnough(swapFee, *dstToken.Decimals) {
+		logWorkerTrace("airdrop", "swap fee is not enough to pay for gas airdrop", "pairID", swap.PairID, "txid", swap.TxID, "swapFee", swapFee, "airdropCost", dstToken.GasAirdrop.GetAirdropCost())
+		return
+	}
+	err := mongodb.AddGasAirdrop(getGasAirdropChain(), swap.PairID, swap.Bind, swap.TxID)
+	if err != nil && !errors.Is(err, mongodb.ErrItemIsDup) {
+		logWorkerError("airdrop", "add gas airdrop failed", err, "pairID", swap.PairID, "bind", swap.Bind, "txid", swap.TxID)
+	}
+}
+
+func loopDoGasAirdropJob(airdropper gasAirdropper) {
+	defer mongodb.MgoWaitGroup.Done()
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			return
+		}
+		logWorkerTrace("airdrop", "start gas airdrop job", "loop", loop)
+		doGasAirdropJob(airdropper)
+		logWorkerTrace("airdrop", "finish gas airdrop job", "loop", loop)
+		time.Sleep(gasAirdropInterval)
+	}
+}
+
+func doGasAirdropJob(airdropper gasAirdropper) {
+	items, err := mongodb.FindGasAirdrops(mongodb.GasAirdropPending, gasAirdropPageLimit)
+	if err != nil {
+		logWorkerError("airdrop", "find pending gas airdrops failed", err)
+		return
+	}
+	for _, item := range items {
+		if utils.IsCleanuping() {
+			return
+		}
+		processGasAirdrop(airdropper, item)
+	}
+}
+
+func processGasAirdrop(airdropper gasAirdropper, item *mongodb.MgoGasAirdrop) {
+	token := tokens.DstBridge.GetTokenConfig(item.PairID)
+	if token == nil || token.GasAirdrop == nil {
+		_ = mongodb.UpdateGasAirdrop(item.Key, mongodb.GasAirdropSkipped, "", "gas airdrop is not configed")
+		return
+	}
+	if !isGasAirdropBudgetEnough(item.PairID, token.GasAirdrop) {
+		return // keep pending until budget is enough
+	}
+	should, err := airdropper.ShouldAirdropGas(item.PairID, item.Bind)
+	if err != nil {
+		logWorkerError("airdrop", "check gas airdrop failed", err, "pairID", item.PairID, "bind", item.Bind)
+		return
+	}
+	if !should {
+		_ = mongodb.UpdateGasAirdrop(item.Key, mongodb.GasAirdropSkipped, "", "balance is not below threshold")
+		return
+	}
+	txHash, err := airdropper.AirdropGas(item.PairID, item.Bind)
+	if err != nil {
+		logWorkerError("airdrop", "send gas airdrop failed", err, "pairID", item.PairID, "bind", item.Bind, "txid", item.SwapTxID)
+		_ = mongodb.UpdateGasAirdrop(item.Key, mongodb.GasAirdropFailed, "", err.Error())
+		return
+	}
+	logWorker("airdrop", "send gas airdrop success", "pairID", item.PairID, "bind", item.Bind, "txid", item.SwapTxID, "txHash", txHash)
+	_ = mongodb.UpdateGasAirdrop(item.Key, mongodb.GasAirdropSent, txHash, "")
+}
+
+func isGasAirdropBudgetEnough(pairID string, cfg *tokens.GasAirdropConfig) bool {
+	count, err := mongodb.CountGasAirdropsSince(pairID, now()-gasAirdropPeriod)
+	if err != nil {
+		logWorkerError("airdrop", "count gas airdrops failed", err, "pairID", pairID)
+		return false
+	}
+	if !cfg.IsBudgetEnough(count) {
+		logWorkerTrace("airdrop", "gas airdrop daily budget is exhausted", "pairID", pairID, "count", count)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	errIndependentVerifyNotSupported = errors.New("bridge does not support independent verify")
+	errIndependentQuorumNotReached   = errors.New("independent gateways quorum not reached")
+)
+
+// independentVerifier verify swap tx through specified api address
+type independentVerifier interface {
+	VerifyTransactionOf(apiAddress string, swapInfo *tokens.TxSwapInfo) (*tokens.TxStatus, error)
+}
+
+// verifyByIndependentGateways verify swap tx through oracle's own gateways,
+// require quorum of gateways agree on block hash, stable confirmations
+// (the same rule as the primary verification) and the swap info.
+func verifyByIndependentGateways(bridge tokens.CrossChainBridge, swapInfo *tokens.TxSwapInfo) error {
+	cfg := params.GetIndependentVerifyConfig()
+	if cfg == nil {
+		return nil
+	}
+	verifier, ok := bridge.(independentVerifier)
+	if !ok {
+		return errIndependentVerifyNotSupported
+	}
+	txHash := swapInfo.Hash
+
+	agreeCount := make(map[string]int) // block hash -> count
+	unstableCount := 0
+	for _, apiAddress := range cfg.GetAPIAddress(bridge.IsSrcEndpoint()) {
+		txStatus, err := verifier.VerifyTransactionOf(apiAddress, swapInfo)
+		if errors.Is(err, tokens.ErrTxNotStable) {
+			unstableCount++
+			continue
+		}
+		if err != nil {
+			logWorkerTrace("accept", "independent gateway verify tx failed", "apiAddress", apiAddress, "txHash", txHash, "err", err)
+			continue
+		}
+		if swapInfo.Height != 0 && swapInfo.Height != txStatus.BlockHeight {
+			logWorkerTrace("accept", "independent gateway block height mismatch", "apiAddress", apiAddress, "txHash", txHash, "have", txStatus.BlockHeight, "want", swapInfo.Height)
+			continue
+		}
+		agreeCount[strings.ToLower(txStatus.BlockHash)]++
+	}
+	for blockHash, count := range agreeCount {
+		if count >= cfg.Quorum {
+			logWorker("accept", "independent gateways quorum reached", "txHash", txHash, "blockHash", blockHash, "count", count, "quorum", cfg.Quorum)
+			return nil
+		}
+	}
+	if unstableCount > 0 {
+		return fmt.Errorf("%w: %v independent gateways report unstable", tokens.ErrTxNotStable, unstableCount)
+	}
+	return fmt.Errorf("%w: quorum is %v, agreements are %v", errIndependentQuorumNotReached, cfg.Quorum, agreeCount)
+}
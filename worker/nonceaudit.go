@@ -0,0 +1,309 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const nonceAuditor = "nonceauditor"
+
+var (
+	maxNonceAuditLifetime = int64(7 * 24 * 3600)
+
+	// only repair problems which are found in consecutive audits.
+	nonceAuditSuspects = make(nonceRecords)
+	// pruned when the nonce is mined, so it's bounded by inflight nonces.
+	reportedDuplicateNonces = make(nonceRecords)
+)
+
+// nonceRecords nonces of accounts, key is isSwapin:account
+type nonceRecords map[string]map[uint64]struct{}
+
+func getNonceAuditKey(isSwapin bool, account string) string {
+	return fmt.Sprintf("%v:%v", isSwapin, account)
+}
+
+func (r nonceRecords) has(key string, nonce uint64) bool {
+	_, exist := r[key][nonce]
+	return exist
+}
+
+func (r nonceRecords) add(key string, nonce uint64) {
+	nonces, exist := r[key]
+	if !exist {
+		nonces = make(map[uint64]struct{})
+		r[key] = nonces
+	}
+	nonces[nonce] = struct{}{}
+}
+
+func (r nonceRecords) remove(key string, nonce uint64) {
+	delete(r[key], nonce)
+	if len(r[key]) == 0 {
+		delete(r, key)
+	}
+}
+
+// prune remove nonces lower than the specified nonce
+func (r nonceRecords) prune(key string, lowerThan uint64) {
+	for nonce := range r[key] {
+		if nonce < lowerThan {
+			r.remove(key, nonce)
+		}
+	}
+}
+
+// StartNonceAuditJob nonce auditor job
+func StartNonceAuditJob() {
+	auditInterval := params.GetServerConfig().NonceAuditInterval
+	if auditInterval <= 0 {
+		logWorker("nonceaudit", "no need to start nonce audit job as disabled")
+		return
+	}
+	if tokens.DstNonceSetter != nil || tokens.SrcNonceSetter != nil {
+		mongodb.MgoWaitGroup.Add(1)
+		go startNonceAuditJob(time.Duration(auditInterval) * time.Second)
+	}
+}
+
+func startNonceAuditJob(auditInterval time.Duration) {
+	logWorker("nonceaudit", "start nonce audit job", "interval", auditInterval)
+	defer mongodb.MgoWaitGroup.Done()
+	for {
+		auditedKeys := make(nonceRecords)
+		if tokens.DstNonceSetter != nil {
+			auditNonces(true, auditedKeys)
+		}
+		if tokens.SrcNonceSetter != nil {
+			auditNonces(false, auditedKeys)
+		}
+		for key, nonces := range nonceAuditSuspects {
+			for nonce := range nonces {
+				if !auditedKeys.has(key, nonce) {
+					nonceAuditSuspects.remove(key, nonce) // problem is gone
+				}
+			}
+		}
+		if utils.IsCleanuping() {
+			logWorker("nonceaudit", "stop nonce audit job")
+			return
+		}
+		restInJob(auditInterval)
+	}
+}
+
+// getDcrmAccountsToAudit get dcrm accounts and one of their pairIDs
+func getDcrmAccountsToAudit(isSwapin bool) map[string]string {
+	accounts := make(map[string]string)
+	for pairID, pairCfg := range tokens.GetTokenPairsConfig() {
+		tokenCfg := pairCfg.SrcToken
+		if isSwapin {
+			tokenCfg = pairCfg.DestToken
+		}
+		accounts[strings.ToLower(tokenCfg.DcrmAddress)] = pairID
+	}
+	return accounts
+}
+
+func auditNonces(isSwapin bool, auditedKeys nonceRecords) {
+	bridge := tokens.GetCrossChainBridge(!isSwapin)
+	if _, ok := bridge.(preSigner); !ok {
+		logWorkerTrace("nonceaudit", "bridge does not support nonce audit", "isSwapin", isSwapin)
+		return
+	}
+	septime := getSepTimeInFind(maxNonceAuditLifetime)
+	results, err := mongodb.FindInflightSwapResults(isSwapin, septime)
+	if err != nil {
+		logWorkerError("nonceaudit", "find inflight swap results failed", err, "isSwapin", isSwapin)
+		return
+	}
+	inflights := make(map[string][]*mongodb.MgoSwapResult) // key is account
+	for _, res := range results {
+		tokenCfg := bridge.GetTokenConfig(res.PairID)
+		if tokenCfg == nil {
+			continue
+		}
+		account := strings.ToLower(tokenCfg.DcrmAddress)
+		inflights[account] = append(inflights[account], res)
+	}
+	for account, pairID := range getDcrmAccountsToAudit(isSwapin) {
+		if utils.IsCleanuping() {
+			return
+		}
+		auditAccountNonce(bridge, isSwapin, account, pairID, inflights[account], auditedKeys)
+	}
+}
+
+func auditAccountNonce(bridge tokens.CrossChainBridge, isSwapin bool, account, pairID string, inflights []*mongodb.MgoSwapResult, auditedKeys nonceRecords) {
+	nonceSetter := bridge.(tokens.NonceSetter)
+	latestNonce, err := nonceSetter.GetPoolNonce(account, "latest")
+	if err != nil {
+		logWorkerError("nonceaudit", "get latest nonce failed", err, "account", account, "isSwapin", isSwapin)
+		return
+	}
+	pendingNonce, err := nonceSetter.GetPoolNonce(account, "pending")
+	if err != nil {
+		logWorkerError("nonceaudit", "get pending nonce failed", err, "account", account, "isSwapin", isSwapin)
+		return
+	}
+	auditKey := getNonceAuditKey(isSwapin, account)
+	reportedDuplicateNonces.prune(auditKey, latestNonce) // mined already
+
+	var storedNonce uint64
+	if item, errf := mongodb.FindLatestSwapNonceOf(account, isSwapin); errf == nil {
+		storedNonce = item.SwapNonce
+	}
+
+	reserver := bridge.(tokens.NonceReserver)
+	duplicates, suspects := findNonceProblems(latestNonce, pendingNonce, storedNonce, inflights,
+		func(swaps []*mongodb.MgoSwapResult) bool { return isAnySwapTxInPool(bridge, swaps) },
+		func(nonce uint64) bool { return reserver.IsNonceReserved(pairID, nonce) },
+	)
+
+	logWorkerTrace("nonceaudit", "audit account nonce", "account", account, "isSwapin", isSwapin,
+		"latestNonce", latestNonce, "pendingNonce", pendingNonce, "storedNonce", storedNonce, "duplicates", len(duplicates), "suspects", len(suspects))
+
+	for nonce, swaps := range duplicates {
+		reportDuplicateNonce(isSwapin, account, nonce, swaps)
+	}
+
+	if storedNonce < pendingNonce {
+		nonceSetter.SetNonce(pairID, pendingNonce)
+		addNonceAuditLog("setnonce", isSwapin, account, pendingNonce, fmt.Sprintf("stored nonce %v is lower than pending nonce", storedNonce))
+	}
+
+	for _, suspect := range suspects {
+		nonce := suspect.nonce
+		auditedKeys.add(auditKey, nonce)
+		if !confirmNonceAuditSuspect(auditKey, nonce) {
+			continue
+		}
+		if suspect.swap != nil {
+			repairDroppedSwapTx(isSwapin, account, nonce, suspect.swap)
+		} else {
+			repairNonceGap(bridge, isSwapin, account, pairID, nonce)
+		}
+		nonceAuditSuspects.remove(auditKey, nonce)
+	}
+}
+
+// nonceSuspect nonce whose swap tx is dropped, or nonce gap if swap is nil
+type nonceSuspect struct {
+	nonce uint64
+	swap  *mongodb.MgoSwapResult
+}
+
+// findNonceProblems find duplicate nonces and suspects in ascending nonce order.
+// nonces lower than pending nonce are in mempool or mined,
+// nonces reserved for pre-signing are skipped as their txs are not sent yet.
+func findNonceProblems(
+	latestNonce, pendingNonce, storedNonce uint64,
+	inflights []*mongodb.MgoSwapResult,
+	isInPool func(swaps []*mongodb.MgoSwapResult) bool,
+	isReserved func(nonce uint64) bool,
+) (duplicates map[uint64][]*mongodb.MgoSwapResult, suspects []*nonceSuspect) {
+	byNonce := make(map[uint64][]*mongodb.MgoSwapResult)
+	maxNonce := storedNonce // exclusive
+	for _, res := range inflights {
+		if res.SwapNonce < latestNonce {
+			continue // mined, handled by stable and replace jobs
+		}
+		byNonce[res.SwapNonce] = append(byNonce[res.SwapNonce], res)
+		if res.SwapNonce+1 > maxNonce {
+			maxNonce = res.SwapNonce + 1
+		}
+	}
+
+	duplicates = make(map[uint64][]*mongodb.MgoSwapResult)
+	for nonce, swaps := range byNonce {
+		if len(swaps) > 1 {
+			duplicates[nonce] = swaps
+		}
+	}
+
+	for nonce := pendingNonce; nonce < maxNonce; nonce++ {
+		if isReserved(nonce) {
+			continue
+		}
+		swaps, exist := byNonce[nonce]
+		if !exist {
+			suspects = append(suspects, &nonceSuspect{nonce: nonce})
+			continue
+		}
+		if !isInPool(swaps) {
+			suspects = append(suspects, &nonceSuspect{nonce: nonce, swap: swaps[0]})
+		}
+	}
+	return duplicates, suspects
+}
+
+func isAnySwapTxInPool(bridge tokens.CrossChainBridge, swaps []*mongodb.MgoSwapResult) bool {
+	for _, res := range swaps {
+		for _, txHash := range append([]string{res.SwapTx}, res.OldSwapTxs...) {
+			if txHash == "" {
+				continue
+			}
+			if _, err := bridge.GetTransaction(txHash); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func confirmNonceAuditSuspect(key string, nonce uint64) bool {
+	if nonceAuditSuspects.has(key, nonce) {
+		return true
+	}
+	nonceAuditSuspects.add(key, nonce)
+	return false
+}
+
+// repairDroppedSwapTx re-send swap tx (by replacing) which is dropped from mempool
+func repairDroppedSwapTx(isSwapin bool, account string, nonce uint64, res *mongodb.MgoSwapResult) {
+	logWorkerWarn("nonceaudit", "found dropped swap tx", "account", account, "nonce", nonce, "isSwapin", isSwapin, "pairID", res.PairID, "txid", res.TxID, "bind", res.Bind, "swaptx", res.SwapTx)
+	txHash, err := replaceSwap(res.TxID, res.PairID, res.Bind, "", isSwapin, false)
+	result := fmt.Sprintf("resend swap (txid %v pairID %v bind %v) with tx %v", res.TxID, res.PairID, res.Bind, txHash)
+	if err != nil {
+		logWorkerError("nonceaudit", "resend dropped swap tx failed", err, "account", account, "nonce", nonce, "isSwapin", isSwapin, "txid", res.TxID)
+		result = fmt.Sprintf("resend swap (txid %v pairID %v bind %v) failed: %v", res.TxID, res.PairID, res.Bind, err)
+	}
+	addNonceAuditLog("resend", isSwapin, account, nonce, result)
+}
+
+// repairNonceGap cancel nonce gap by zero value self transfer
+func repairNonceGap(bridge tokens.CrossChainBridge, isSwapin bool, account, pairID string, nonce uint64) {
+	logWorkerWarn("nonceaudit", "found nonce gap", "account", account, "nonce", nonce, "isSwapin", isSwapin)
+	txHash, err := fillNonceGap(bridge, pairID, nonce)
+	result := fmt.Sprintf("fill nonce gap with tx %v", txHash)
+	if err != nil {
+		result = fmt.Sprintf("fill nonce gap failed: %v", err)
+	}
+	addNonceAuditLog("fillnonce", isSwapin, account, nonce, result)
+}
+
+func reportDuplicateNonce(isSwapin bool, account string, nonce uint64, swaps []*mongodb.MgoSwapResult) {
+	key := getNonceAuditKey(isSwapin, account)
+	if reportedDuplicateNonces.has(key, nonce) {
+		return
+	}
+	reportedDuplicateNonces.add(key, nonce)
+	txids := make([]string, len(swaps))
+	for i, res := range swaps {
+		txids[i] = res.TxID
+	}
+	logWorkerWarn("nonceaudit", "found duplicate swap nonce", "account", account, "nonce", nonce, "isSwapin", isSwapin, "txids", txids)
+	addNonceAuditLog("duplicate", isSwapin, account, nonce, fmt.Sprintf("swaps %v have the same nonce", txids))
+}
+
+func addNonceAuditLog(method string, isSwapin bool, account string, nonce uint64, result string) {
+	swapType := getSwapType(isSwapin).String()
+	logParams := []string{swapType, account, fmt.Sprintf("%v", nonce)}
+	_ = mongodb.AddAdminLog(nonceAuditor, method, logParams, result)
+}
@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+)
+
+func TestFindNonceProblems(t *testing.T) {
+	swap := func(txid string, nonce uint64) *mongodb.MgoSwapResult {
+		return &mongodb.MgoSwapResult{TxID: txid, SwapNonce: nonce}
+	}
+	a5, b5, c6, d7, e3 := swap("a", 5), swap("b", 5), swap("c", 6), swap("d", 7), swap("e", 3)
+
+	tests := []struct {
+		name           string
+		latestNonce    uint64
+		pendingNonce   uint64
+		storedNonce    uint64
+		inflights      []*mongodb.MgoSwapResult
+		inPool         map[string]bool
+		reserved       []uint64
+		wantDuplicates map[uint64][]*mongodb.MgoSwapResult
+		wantSuspects   []*nonceSuspect
+	}{
+		{
+			name:        "all in pool",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 7,
+			inflights: []*mongodb.MgoSwapResult{a5, c6},
+			inPool:    map[string]bool{"a": true, "c": true},
+		},
+		{
+			name:        "nonce gap",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 8,
+			inflights:    []*mongodb.MgoSwapResult{a5, d7},
+			inPool:       map[string]bool{"a": true, "d": true},
+			wantSuspects: []*nonceSuspect{{nonce: 6}},
+		},
+		{
+			name:        "gap up to stored nonce",
+			latestNonce: 5, pendingNonce: 6, storedNonce: 8,
+			inflights:    []*mongodb.MgoSwapResult{c6},
+			inPool:       map[string]bool{"c": true},
+			wantSuspects: []*nonceSuspect{{nonce: 7}},
+		},
+		{
+			name:        "dropped tx",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 7,
+			inflights:    []*mongodb.MgoSwapResult{a5, c6},
+			inPool:       map[string]bool{"a": true},
+			wantSuspects: []*nonceSuspect{{nonce: 6, swap: c6}},
+		},
+		{
+			name:        "lower than pending nonce is not dropped",
+			latestNonce: 5, pendingNonce: 7, storedNonce: 7,
+			inflights: []*mongodb.MgoSwapResult{a5, c6},
+		},
+		{
+			name:        "mined is ignored",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 5,
+			inflights: []*mongodb.MgoSwapResult{e3},
+		},
+		{
+			name:        "duplicate nonce",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 6,
+			inflights:      []*mongodb.MgoSwapResult{a5, b5},
+			inPool:         map[string]bool{"b": true},
+			wantDuplicates: map[uint64][]*mongodb.MgoSwapResult{5: {a5, b5}},
+		},
+		{
+			name:        "reserved nonces are skipped",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 6,
+			inflights:    []*mongodb.MgoSwapResult{a5, d7},
+			inPool:       map[string]bool{"a": true},
+			reserved:     []uint64{6, 7},
+			wantSuspects: nil,
+		},
+		{
+			name:        "gap beside reserved nonce",
+			latestNonce: 5, pendingNonce: 5, storedNonce: 5,
+			inflights:    []*mongodb.MgoSwapResult{d7},
+			reserved:     []uint64{5, 7},
+			wantSuspects: []*nonceSuspect{{nonce: 6}},
+		},
+	}
+
+	for _, test := range tests {
+		isInPool := func(swaps []*mongodb.MgoSwapResult) bool {
+			for _, res := range swaps {
+				if test.inPool[res.TxID] {
+					return true
+				}
+			}
+			return false
+		}
+		isReserved := func(nonce uint64) bool {
+			for _, reserved := range test.reserved {
+				if nonce == reserved {
+					return true
+				}
+			}
+			return false
+		}
+		duplicates, suspects := findNonceProblems(test.latestNonce, test.pendingNonce, test.storedNonce, test.inflights, isInPool, isReserved)
+		if test.wantDuplicates == nil {
+			test.wantDuplicates = make(map[uint64][]*mongodb.MgoSwapResult)
+		}
+		if !reflect.DeepEqual(duplicates, test.wantDuplicates) {
+			t.Errorf("%v: want duplicates %v, got %v", test.name, test.wantDuplicates, duplicates)
+		}
+		if !reflect.DeepEqual(suspects, test.wantSuspects) {
+			t.Errorf("%v: want suspects %v, got %v", test.name, test.wantSuspects, suspects)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// policyError policy rule violated error
+type policyError struct {
+	rule   string
+	reason string
+	cause  error
+}
+
+func (e *policyError) Error() string {
+	return fmt.Sprintf("policy rule '%v' violated: %v", e.rule, e.reason)
+}
+
+func (e *policyError) Unwrap() error {
+	return e.cause
+}
+
+func newPolicyError(rule *params.PolicyRule, cause error, format string, args ...interface{}) error {
+	return &policyError{rule: rule.Name, reason: fmt.Sprintf(format, args...), cause: cause}
+}
+
+// outflowRecord value of agreed swap (whole unit of token),
+// it is persisted in accept database to survive restarts.
+type outflowRecord struct {
+	Timestamp int64   `json:"timestamp"`
+	PairID    string  `json:"pairid"`
+	Value     float64 `json:"value"`
+}
+
+const outflowKeyPrefix = "outflow:"
+
+var (
+	outflowRecords     = make(map[string]*outflowRecord) // key is swap key
+	outflowRecordsLock sync.Mutex
+)
+
+// checkPolicyRules evaluate oracle policy rules before agreeing
+// (outflow cap rules are checked by reserveOutflow)
+func checkPolicyRules(srcBridge tokens.CrossChainBridge, args *tokens.BuildTxArgs, swapInfo *tokens.TxSwapInfo) error {
+	policy := params.GetPolicyConfig()
+	if policy == nil || len(policy.Rules) == 0 {
+		return nil
+	}
+	tokenCfg := srcBridge.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+	value := tokens.FromBits(swapInfo.Value, *tokenCfg.Decimals)
+	swapType := args.SwapType.String()
+	nowTime := time.Now()
+
+	for _, rule := range policy.Rules {
+		if !rule.IsMatch(args.PairID, swapType) {
+			continue
+		}
+		if !rule.IsInAllowedTime(nowTime) {
+			return newPolicyError(rule, nil, "time %v is not in allowed time ranges %v", nowTime.UTC().Format("15:04"), rule.AllowedTimeRanges)
+		}
+		for _, addr := range []string{args.Bind, swapInfo.Bind, swapInfo.From, swapInfo.TxTo} {
+			if rule.IsDeniedAddress(addr) {
+				return newPolicyError(rule, nil, "address %v is denied", addr)
+			}
+		}
+		if rule.MaxValue > 0 && value > rule.MaxValue {
+			return newPolicyError(rule, nil, "value %v exceeds max value %v", value, rule.MaxValue)
+		}
+		if rule.MinConfirmations > 0 {
+			if err := checkPolicyConfirmations(srcBridge, rule, swapInfo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkPolicyConfirmations(srcBridge tokens.CrossChainBridge, rule *params.PolicyRule, swapInfo *tokens.TxSwapInfo) error {
+	if swapInfo.Height == 0 {
+		return newPolicyError(rule, tokens.ErrTxNotStable, "tx is not on chain")
+	}
+	latest, err := srcBridge.GetLatestBlockNumber()
+	if err != nil {
+		return err
+	}
+	var confirmations uint64
+	if latest >= swapInfo.Height {
+		confirmations = latest - swapInfo.Height + 1
+	}
+	if confirmations < rule.MinConfirmations {
+		return newPolicyError(rule, tokens.ErrTxNotStable, "confirmations %v is less than %v", confirmations, rule.MinConfirmations)
+	}
+	return nil
+}
+
+func getPolicySwapKey(args *tokens.BuildTxArgs) string {
+	return strings.ToLower(fmt.Sprintf("%s:%d:%s:%s", args.SwapID, args.SwapType, args.PairID, args.Bind))
+}
+
+func getMaxPolicyWindow() (maxWindow int64) {
+	policy := params.GetPolicyConfig()
+	if policy == nil {
+		return 0
+	}
+	for _, rule := range policy.Rules {
+		if rule.WindowSeconds > maxWindow {
+			maxWindow = rule.WindowSeconds
+		}
+	}
+	return maxWindow
+}
+
+// reserveOutflow check outflow cap rules and record the swap value
+// under one lock, so concurrent agreements can not exceed the cap.
+// the returned release func should be called if the swap is not agreed at last.
+func reserveOutflow(srcBridge tokens.CrossChainBridge, args *tokens.BuildTxArgs, swapInfo *tokens.TxSwapInfo) (release func(), err error) {
+	release = func() {}
+	maxWindow := getMaxPolicyWindow()
+	if maxWindow == 0 {
+		return release, nil
+	}
+	tokenCfg := srcBridge.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return release, tokens.ErrUnknownPairID
+	}
+	value := tokens.FromBits(swapInfo.Value, *tokenCfg.Decimals)
+	swapType := args.SwapType.String()
+	swapKey := getPolicySwapKey(args)
+
+	outflowRecordsLock.Lock()
+	defer outflowRecordsLock.Unlock()
+
+	nowTime := now()
+	pruneOutflowRecords(nowTime - maxWindow)
+	if _, exist := outflowRecords[swapKey]; exist {
+		return release, nil // already recorded
+	}
+	for _, rule := range params.GetPolicyConfig().Rules {
+		if rule.WindowSeconds == 0 || !rule.IsMatch(args.PairID, swapType) {
+			continue
+		}
+		sum := getOutflowSum(args.PairID, nowTime-rule.WindowSeconds)
+		if sum+value > rule.MaxWindowValue {
+			return release, newPolicyError(rule, nil, "outflow %v in %v seconds exceeds max value %v", sum+value, rule.WindowSeconds, rule.MaxWindowValue)
+		}
+	}
+	record := &outflowRecord{
+		Timestamp: nowTime,
+		PairID:    args.PairID,
+		Value:     value,
+	}
+	outflowRecords[swapKey] = record
+	saveOutflowRecord(swapKey, record)
+
+	release = func() {
+		outflowRecordsLock.Lock()
+		defer outflowRecordsLock.Unlock()
+		if outflowRecords[swapKey] == record {
+			delete(outflowRecords, swapKey)
+			deleteOutflowRecord(swapKey)
+		}
+	}
+	return release, nil
+}
+
+// getOutflowSum sum value of agreed swaps of the pair since the time
+// (caller should hold outflowRecordsLock)
+func getOutflowSum(pairID string, since int64) (sum float64) {
+	for _, record := range outflowRecords {
+		if record.Timestamp >= since && strings.EqualFold(record.PairID, pairID) {
+			sum += record.Value
+		}
+	}
+	return sum
+}
+
+// pruneOutflowRecords delete records older than the time
+// (caller should hold outflowRecordsLock)
+func pruneOutflowRecords(before int64) {
+	for key, record := range outflowRecords {
+		if record.Timestamp < before {
+			delete(outflowRecords, key)
+			deleteOutflowRecord(key)
+		}
+	}
+}
+
+func saveOutflowRecord(swapKey string, record *outflowRecord) {
+	if lvldbHandle == nil {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err == nil {
+		err = lvldbHandle.Put([]byte(outflowKeyPrefix+swapKey), data)
+	}
+	if err != nil {
+		logWorkerError("policy", "save outflow record failed", err, "key", swapKey)
+	}
+}
+
+func deleteOutflowRecord(swapKey string) {
+	if lvldbHandle == nil {
+		return
+	}
+	err := lvldbHandle.Delete([]byte(outflowKeyPrefix + swapKey))
+	if err != nil {
+		logWorkerError("policy", "delete outflow record failed", err, "key", swapKey)
+	}
+}
+
+// loadOutflowRecords load outflow records in window from accept database
+func loadOutflowRecords() {
+	maxWindow := getMaxPolicyWindow()
+	if lvldbHandle == nil || maxWindow == 0 {
+		return
+	}
+
+	outflowRecordsLock.Lock()
+	defer outflowRecordsLock.Unlock()
+
+	iter := lvldbHandle.NewIterator([]byte(outflowKeyPrefix), nil)
+	for iter.Next() {
+		swapKey := strings.TrimPrefix(string(iter.Key()), outflowKeyPrefix)
+		var record outflowRecord
+		if err := json.Unmarshal(iter.Value(), &record); err != nil {
+			logWorkerError("policy", "parse outflow record failed", err, "key", swapKey)
+			continue
+		}
+		outflowRecords[swapKey] = &record
+	}
+	iter.Release()
+	pruneOutflowRecords(now() - maxWindow)
+	logWorker("policy", "load outflow records success", "count", len(outflowRecords))
+}
@@ -0,0 +1,179 @@
+package worker
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// preSigner reserve nonces ahead and fill nonce gaps (for eth-like)
+type preSigner interface {
+	tokens.NonceReserver
+	BuildFillNonceTransaction(pairID string, nonce uint64) (rawTx interface{}, args *tokens.BuildTxArgs, err error)
+}
+
+func isPreSignEnabled() bool {
+	serverCfg := params.GetServerConfig()
+	return serverCfg != nil && serverCfg.PreSignCount > 1
+}
+
+// preSignSwap swap task in pre-signing
+type preSignSwap struct {
+	args       *tokens.BuildTxArgs
+	cacheKey   string
+	rawTx      interface{}
+	signedTx   interface{}
+	signTxHash string
+	signErr    error
+	processed  bool
+}
+
+// doSwapPreSign reserve nonces and build txs, sign them concurrently,
+// and then update database and send txs in nonce order.
+// nonce gaps of failed signing are filled by re-signing or zero value self transfer.
+func doSwapPreSign(argsList []*tokens.BuildTxArgs) {
+	isSwapin := argsList[0].SwapType == tokens.SwapinType
+	resBridge := tokens.GetCrossChainBridge(!isSwapin)
+	reserver := resBridge.(preSigner)
+	nonceSetter := resBridge.(tokens.NonceSetter)
+
+	swaps := make([]*preSignSwap, 0, len(argsList))
+	defer func() {
+		for _, swap := range swaps {
+			if !swap.processed {
+				args := swap.args
+				logWorker("doSwapPreSign", "delete swap cache", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin)
+				cachedSwapTasks.Remove(swap.cacheKey)
+			}
+		}
+	}()
+
+	poolNonce, err := nonceSetter.GetPoolNonce(argsList[0].From, "pending")
+	if err != nil {
+		logWorkerError("doSwapPreSign", "get pool nonce failed", err, "account", argsList[0].From, "isSwapin", isSwapin)
+		return
+	}
+
+	for _, args := range argsList {
+		cacheKey := getSwapCacheKey(isSwapin, args.SwapID, args.PairID, args.Bind)
+		if checkAndUpdateProcessSwapTaskCache(cacheKey) != nil {
+			continue
+		}
+		nonce := reserver.ReserveNonce(args.PairID, poolNonce)
+		args.Extra = &tokens.AllExtras{EthExtra: &tokens.EthExtraArgs{Nonce: &nonce}}
+		rawTx, err := resBridge.BuildRawTransaction(args)
+		if err != nil {
+			logWorkerError("doSwapPreSign", "build tx failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "nonce", nonce)
+			markSwapTxWillRevert(args, err)
+			reserver.ReleaseNonce(args.PairID, nonce)
+			cachedSwapTasks.Remove(cacheKey)
+			continue
+		}
+		if err = simulateSwapTx(resBridge, rawTx, args); err != nil {
+			reserver.ReleaseNonce(args.PairID, nonce)
+			cachedSwapTasks.Remove(cacheKey)
+			continue
+		}
+		swaps = append(swaps, &preSignSwap{args: args, cacheKey: cacheKey, rawTx: rawTx})
+	}
+	if len(swaps) == 0 {
+		return
+	}
+
+	logWorker("doSwapPreSign", "start to sign", "count", len(swaps), "isSwapin", isSwapin, "firstNonce", swaps[0].args.GetTxNonce())
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(swaps))
+	for _, swap := range swaps {
+		go func(swap *preSignSwap) {
+			defer wg.Done()
+			swap.signedTx, swap.signTxHash, swap.signErr = dcrmSignWithRetry(resBridge, swap.rawTx, swap.args, 3)
+		}(swap)
+	}
+	wg.Wait()
+
+	// send in nonce order
+	for _, swap := range swaps {
+		sendPreSignedSwap(resBridge, swap)
+		reserver.ReleaseNonce(swap.args.PairID, swap.args.GetTxNonce())
+	}
+}
+
+// sendPreSignedSwap send pre-signed swap tx, or fill its nonce if failed
+func sendPreSignedSwap(resBridge tokens.CrossChainBridge, swap *preSignSwap) {
+	args := swap.args
+	isSwapin := args.SwapType == tokens.SwapinType
+	nonce := args.GetTxNonce()
+	if swap.signErr != nil && !errors.Is(swap.signErr, dcrm.ErrGetSignStatusHasDisagree) {
+		logWorkerWarn("doSwapPreSign", "re-sign swap to fill nonce", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "nonce", nonce, "err", swap.signErr)
+		swap.signedTx, swap.signTxHash, swap.signErr = dcrmSignWithRetry(resBridge, swap.rawTx, swap.args, 1)
+	}
+	if swap.signErr != nil {
+		if errors.Is(swap.signErr, dcrm.ErrGetSignStatusHasDisagree) {
+			reverifySwap(args)
+		}
+		logWorkerError("doSwapPreSign", "sign tx failed", swap.signErr, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "nonce", nonce)
+		_, _ = fillNonceGap(resBridge, args.PairID, nonce)
+		return
+	}
+	var err error
+	swap.processed, err = finishSwap(resBridge, args, swap.signedTx, swap.signTxHash)
+	if err != nil {
+		if !errors.Is(err, errAlreadySwapped) {
+			logWorkerError("doSwapPreSign", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "nonce", nonce)
+		}
+		if !swap.processed { // signed tx is not recorded and sent
+			_, _ = fillNonceGap(resBridge, args.PairID, nonce)
+		}
+	}
+}
+
+func dcrmSignWithRetry(resBridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs, retryCount int) (signedTx interface{}, txHash string, err error) {
+	for i := 1; i <= retryCount; i++ {
+		signedTx, txHash, err = resBridge.DcrmSignTransaction(rawTx, args)
+		if err == nil || errors.Is(err, dcrm.ErrGetSignStatusHasDisagree) {
+			break
+		}
+		logWorkerTrace("doSwapPreSign", "sign tx failed", "pairID", args.PairID, "txid", args.SwapID, "nonce", args.GetTxNonce(), "signCount", i, "err", err)
+		if i < retryCount {
+			restInJob(retrySignInterval)
+		}
+	}
+	return signedTx, txHash, err
+}
+
+// fillNonceGap fill nonce gap with zero value self transfer
+func fillNonceGap(resBridge tokens.CrossChainBridge, pairID string, nonce uint64) (txHash string, err error) {
+	filler, ok := resBridge.(preSigner)
+	if !ok {
+		return "", errNotNonceSupport
+	}
+	rawTx, args, err := filler.BuildFillNonceTransaction(pairID, nonce)
+	if err != nil {
+		logWorkerError("fillNonce", "build fill nonce tx failed", err, "pairID", pairID, "nonce", nonce)
+		return "", err
+	}
+	var signedTx interface{}
+	if privKey := resBridge.GetTokenConfig(pairID).GetDcrmAddressPrivateKey(); privKey != nil {
+		signedTx, txHash, err = resBridge.SignTransaction(rawTx, pairID)
+	} else {
+		signedTx, txHash, err = dcrmSignWithRetry(resBridge, rawTx, args, 3)
+	}
+	if err != nil {
+		logWorkerError("fillNonce", "sign fill nonce tx failed", err, "pairID", pairID, "nonce", nonce)
+		return "", err
+	}
+	_, err = resBridge.SendTransaction(signedTx)
+	if err != nil {
+		logWorkerError("fillNonce", "send fill nonce tx failed", err, "pairID", pairID, "nonce", nonce, "txHash", txHash)
+		return txHash, err
+	}
+	if nonceSetter, ok := resBridge.(tokens.NonceSetter); ok {
+		nonceSetter.SetNonce(pairID, nonce+1)
+	}
+	logWorker("fillNonce", "fill nonce gap success", "pairID", pairID, "nonce", nonce, "txHash", txHash)
+	return txHash, nil
+}
@@ -180,6 +180,7 @@ func replaceSwap(txid, pairID, bind, gasPriceStr string, isSwapin, isManual bool
 		OriginFrom:  swap.From,
 		OriginTxTo:  swap.TxTo,
 		OriginValue: swapInfo.Value,
+		OriginCall:  swapInfo.Call,
 		Extra: &tokens.AllExtras{
 			EthExtra: &tokens.EthExtraArgs{
 				GasPrice: gasPrice,
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
@@ -55,3 +56,33 @@ func updateHeartbeat() {
 		logWorker("reportstat", "report stat success", "timestamp", timestamp)
 	}
 }
+
+// reportSignDisagree report disagree reason to server for inspection
+func reportSignDisagree(keyID, reason string) {
+	if params.ServerAPIAddress == "" {
+		return
+	}
+	enode := dcrm.GetSelfEnode()
+	timestamp := now()
+	msgHash := dcrm.GetSignDisagreeMsgHash(dcrm.GetEnodeID(enode), keyID, reason, timestamp)
+	signature, err := dcrm.SignWithDcrmUser(msgHash)
+	if err != nil {
+		logWorkerWarn("reportstat", "sign disagree report failed", "keyID", keyID, "err", err)
+		return
+	}
+	method := "swap.ReportSignDisagree"
+	args := map[string]interface{}{
+		"enode":     enode,
+		"keyID":     keyID,
+		"reason":    reason,
+		"timestamp": timestamp,
+		"signature": common.ToHex(signature),
+	}
+	var result string
+	err = client.RPCPostWithTimeout(20, &result, params.ServerAPIAddress, method, args)
+	if err != nil {
+		logWorkerWarn("reportstat", "report sign disagree failed", "keyID", keyID, "err", err)
+	} else {
+		logWorker("reportstat", "report sign disagree success", "keyID", keyID)
+	}
+}
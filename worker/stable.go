@@ -151,7 +151,11 @@ func processSwapStable(swap *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 			logWorkerWarn("stable", "mark swap result failed with wrong status", "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "isSwapin", isSwapin, "swaptime", swap.Timestamp, "nowtime", now(), "confirmations", txStatus.Confirmations)
 			return markSwapResultFailed(swap.TxID, swap.PairID, swap.Bind, isSwapin)
 		}
-		return markSwapResultStable(swap.TxID, swap.PairID, swap.Bind, isSwapin)
+		err = markSwapResultStable(swap.TxID, swap.PairID, swap.Bind, isSwapin)
+		if err == nil && isSwapin {
+			addGasAirdrop(swap)
+		}
+		return err
 	}
 
 	return updateSwapResultHeight(swap, txStatus.BlockHeight, txStatus.BlockTime, swap.SwapTx != oldSwapTx)
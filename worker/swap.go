@@ -245,6 +245,7 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 		OriginFrom:  swap.From,
 		OriginTxTo:  swap.TxTo,
 		OriginValue: swapInfo.Value,
+		OriginCall:  swapInfo.Call,
 	}
 
 	return dispatchSwapTask(args)
@@ -400,21 +401,44 @@ func processSwapTask(swapChan <-chan *tokens.BuildTxArgs, dcrmAddress string, is
 			logWorker("doSwap", "stop process swap task", "isSwapin", isSwapin, "dcrmAddress", dcrmAddress)
 			return
 		case args := <-swapChan:
+			if !isSwapin && isSweepTask(args) {
+				doSweepTask(args)
+				continue
+			}
 			if !strings.EqualFold(args.From, dcrmAddress) || args.SwapType != getSwapType(isSwapin) {
 				logWorkerWarn("doSwap", "ignore swap task as mismatch reason", "isSwapin", isSwapin, "dcrmAddress", dcrmAddress, "args", args)
 				continue
 			}
-			err := doSwap(args)
+			batch, others := collectBatchSwapTasks(swapChan, args, dcrmAddress, isSwapin)
 			switch {
-			case err == nil,
-				errors.Is(err, errAlreadySwapped):
+			case len(batch) <= 1:
+				others = append(batch, others...)
+			case isPreSignEnabled():
+				doSwapPreSign(batch)
 			default:
-				logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+				doSwapBatch(batch)
+			}
+			for _, swapArgs := range others {
+				doSwapTask(swapArgs)
 			}
 		}
 	}
 }
 
+func doSwapTask(args *tokens.BuildTxArgs) {
+	if isSweepTask(args) {
+		doSweepTask(args)
+		return
+	}
+	err := doSwap(args)
+	switch {
+	case err == nil,
+		errors.Is(err, errAlreadySwapped):
+	default:
+		logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+	}
+}
+
 func getSwapCacheKey(isSwapin bool, txid, pairID, bind string) string {
 	return strings.ToLower(fmt.Sprintf("%s:%s:%s:%t", pairID, txid, bind, isSwapin))
 }
@@ -458,10 +482,14 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	rawTx, err := resBridge.BuildRawTransaction(args)
 	if err != nil {
 		logWorkerError("doSwap", "build tx failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		markSwapTxWillRevert(args, err)
 		return err
 	}
 
-	swapNonce := args.GetTxNonce()
+	err = simulateSwapTx(resBridge, rawTx, args)
+	if err != nil {
+		return err
+	}
 
 	var signedTx interface{}
 	var signTxHash string
@@ -485,14 +513,27 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		return err
 	}
 
+	isCachedSwapProcessed, err = finishSwap(resBridge, args, signedTx, signTxHash)
+	return err
+}
+
+// finishSwap update database and send the signed tx
+func finishSwap(resBridge tokens.CrossChainBridge, args *tokens.BuildTxArgs, signedTx interface{}, signTxHash string) (isProcessed bool, err error) {
+	pairID := args.PairID
+	txid := args.SwapID
+	bind := args.Bind
+	swapType := args.SwapType
+	isSwapin := swapType == tokens.SwapinType
+	swapNonce := args.GetTxNonce()
+
 	// recheck reswap before update db
 	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
 	if err != nil {
-		return err
+		return false, err
 	}
 	err = preventReswap(res, isSwapin)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// update database before sending transaction
@@ -509,14 +550,13 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	err = updateSwapResult(txid, pairID, bind, matchTx)
 	if err != nil {
 		logWorkerError("doSwap", "update swap result failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
-		return err
+		return false, err
 	}
-	isCachedSwapProcessed = true
 
 	err = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxProcessed, now(), "")
 	if err != nil {
 		logWorkerError("doSwap", "update swap status failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
-		return err
+		return true, err
 	}
 
 	txHash, err := sendSignedTransaction(resBridge, signedTx, args)
@@ -524,7 +564,7 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		logWorkerError("doSwap", "send tx success but with different hash", errSendTxWithDiffHash, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "swapNonce", swapNonce, "txHash", txHash, "signTxHash", signTxHash)
 		_ = mongodb.UpdateSwapResultOldTxs(txid, pairID, bind, txHash, matchTx.SwapValue, isSwapin)
 	}
-	return err
+	return true, err
 }
 
 func reverifySwap(args *tokens.BuildTxArgs) {
@@ -548,6 +588,32 @@ func reverifySwap(args *tokens.BuildTxArgs) {
 	}
 }
 
+// simulateSwapTx dry run swap tx before signing if supported,
+// swap which will revert is marked as retriable `TxSimulateFailed`.
+// reverts found by gas estimation when building tx are marked the same way.
+func simulateSwapTx(bridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs) error {
+	simulator, ok := bridge.(interface {
+		SimulateSwapTx(rawTx interface{}, args *tokens.BuildTxArgs) error
+	})
+	if !ok {
+		return nil
+	}
+	err := simulator.SimulateSwapTx(rawTx, args)
+	markSwapTxWillRevert(args, err)
+	return err
+}
+
+// markSwapTxWillRevert mark swap as retriable `TxSimulateFailed` with revert reason in memo,
+// if building (gas estimation) or simulating swap tx failed as it will revert
+func markSwapTxWillRevert(args *tokens.BuildTxArgs, err error) {
+	if !errors.Is(err, tokens.ErrSwapTxWillRevert) {
+		return
+	}
+	isSwapin := args.IsSwapin()
+	logWorkerWarn("doSwap", "swap tx will revert", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "err", err)
+	_ = mongodb.UpdateSwapStatus(isSwapin, args.SwapID, args.PairID, args.Bind, mongodb.TxSimulateFailed, now(), err.Error())
+}
+
 // DeleteCachedSwap delete cached swap
 func DeleteCachedSwap(isSwapin bool, txid, pairID, bind string) {
 	cacheKey := getSwapCacheKey(isSwapin, txid, pairID, bind)
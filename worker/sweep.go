@@ -0,0 +1,165 @@
+package worker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	depositAddressPageLimit = 100
+
+	sweepInterval = 10 * time.Minute
+)
+
+// depositSweeper sweep balances of per user deposit addresses
+type depositSweeper interface {
+	ShouldSweepDepositAddress(pairID, depositAddress string) bool
+	BuildSweepTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error)
+}
+
+// StartSweepJob sweep job
+func StartSweepJob() {
+	sweeper, ok := tokens.SrcBridge.(depositSweeper)
+	if !ok {
+		return
+	}
+	if !hasDepositForwarder() {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopDoSweepJob(sweeper)
+}
+
+func hasDepositForwarder() bool {
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		if pairCfg.SrcToken.HasDepositForwarder() {
+			return true
+		}
+	}
+	return false
+}
+
+func loopDoSweepJob(sweeper depositSweeper) {
+	defer mongodb.MgoWaitGroup.Done()
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			return
+		}
+		logWorker("sweep", "start sweep job", "loop", loop)
+		doSweepJob(sweeper)
+		logWorker("sweep", "finish sweep job", "loop", loop)
+		time.Sleep(sweepInterval)
+	}
+}
+
+func doSweepJob(sweeper depositSweeper) {
+	offset := 0
+	for {
+		if utils.IsCleanuping() {
+			return
+		}
+		depositAddrs, err := mongodb.FindDepositAddresses(offset, depositAddressPageLimit)
+		if err != nil {
+			logWorkerError("sweep", "FindDepositAddresses failed", err, "offset", offset, "limit", depositAddressPageLimit)
+			time.Sleep(3 * time.Second)
+			continue
+		}
+		for _, depositAddr := range depositAddrs {
+			sweepDepositAddress(sweeper, depositAddr)
+		}
+		if len(depositAddrs) < depositAddressPageLimit {
+			break
+		}
+		offset += depositAddressPageLimit
+	}
+}
+
+// sweepDepositAddress dispatch sweep task to the swapout task channel of dcrm address,
+// so that sweep txs are built and sent serially with swapout txs (no nonce collision)
+func sweepDepositAddress(sweeper depositSweeper, depositAddr *mongodb.MgoDepositAddress) {
+	pairID := depositAddr.PairID
+	tokenCfg := tokens.SrcBridge.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return
+	}
+	if !sweeper.ShouldSweepDepositAddress(pairID, depositAddr.Key) {
+		return
+	}
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			Identifier: tokens.SweepIdentifier,
+			PairID:     pairID,
+			SwapID:     depositAddr.Key, // deposit address
+			Bind:       depositAddr.BindAddress,
+		},
+		From: tokenCfg.DcrmAddress,
+	}
+	swapChan, exist := swapoutTaskChanMap[strings.ToLower(args.From)]
+	if !exist {
+		logWorkerWarn("sweep", "no swapout task channel for dcrm address", "pairID", pairID, "dcrmAddress", args.From)
+		return
+	}
+	select {
+	case swapChan <- args:
+		logWorker("sweep", "dispatch sweep task", "pairID", pairID, "address", depositAddr.Key, "bind", depositAddr.BindAddress)
+	default:
+		logWorkerWarn("sweep", "swap task channel is full", "pairID", pairID, "address", depositAddr.Key, "bind", depositAddr.BindAddress)
+	}
+}
+
+func isSweepTask(args *tokens.BuildTxArgs) bool {
+	return args.Identifier == tokens.SweepIdentifier
+}
+
+// doSweepTask build, sign, record and send sweep tx
+func doSweepTask(args *tokens.BuildTxArgs) {
+	bridge := tokens.SrcBridge
+	sweeper, ok := bridge.(depositSweeper)
+	if !ok {
+		return
+	}
+	pairID, depositAddress, bind := args.PairID, args.SwapID, args.Bind
+	rawTx, err := sweeper.BuildSweepTransaction(args)
+	if err != nil {
+		logWorkerError("sweep", "build sweep tx failed", err, "pairID", pairID, "address", depositAddress, "bind", bind)
+		return
+	}
+	var signedTx interface{}
+	var txHash string
+	tokenCfg := bridge.GetTokenConfig(pairID)
+	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
+		signedTx, txHash, err = bridge.SignTransaction(rawTx, pairID)
+	} else {
+		signedTx, txHash, err = bridge.DcrmSignTransaction(rawTx, args)
+	}
+	if err != nil {
+		logWorkerError("sweep", "sign sweep tx failed", err, "pairID", pairID, "address", depositAddress, "bind", bind)
+		return
+	}
+	nonce := args.GetTxNonce()
+	err = mongodb.AddSweepTx(&mongodb.MgoSweepTx{
+		Key:            strings.ToLower(txHash),
+		PairID:         strings.ToLower(pairID),
+		BindAddress:    bind,
+		DepositAddress: depositAddress,
+		From:           strings.ToLower(args.From),
+		Nonce:          nonce,
+	})
+	if err != nil {
+		return
+	}
+	_, err = bridge.SendTransaction(signedTx)
+	if err != nil {
+		logWorkerError("sweep", "send sweep tx failed", err, "pairID", pairID, "address", depositAddress, "bind", bind, "txHash", txHash, "nonce", nonce)
+		return
+	}
+	if nonceSetter, ok := bridge.(tokens.NonceSetter); ok {
+		nonceSetter.SetNonce(pairID, nonce+1) // increase for next usage
+	}
+	logWorker("sweep", "send sweep tx success", "pairID", pairID, "address", depositAddress, "bind", bind, "txHash", txHash, "nonce", nonce)
+}
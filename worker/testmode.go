@@ -83,6 +83,7 @@ func process(opts map[string]string) error {
 		OriginFrom:  swapInfo.From,
 		OriginTxTo:  swapInfo.TxTo,
 		OriginValue: swapInfo.Value,
+		OriginCall:  swapInfo.Call,
 	}
 	rawTx, err := dstBridge.BuildRawTransaction(args)
 	if err != nil {
@@ -0,0 +1,249 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools"
+)
+
+const tokenMonitor = "tokenmonitor"
+
+var (
+	defaultTokenMonitorInterval   = int64(60)
+	defaultTokenMonitorBlockRange = uint64(1000)
+)
+
+// mappingTokenMonitor scan mint, burn and role change events of mapping tokens
+type mappingTokenMonitor interface {
+	ScanMappingTokenEvents(contracts []string, fromHeight, toHeight uint64) ([]*tokens.MappingTokenEvent, error)
+}
+
+// StartMappingTokenMonitorJob mapping token monitor job
+func StartMappingTokenMonitorJob() {
+	restoreMappingTokenPausedPairs()
+
+	serverCfg := params.GetServerConfig()
+	if serverCfg == nil || serverCfg.MappingTokenMonitor == nil {
+		logWorker(tokenMonitor, "no need to start mapping token monitor job as not configed")
+		return
+	}
+	monitor, ok := tokens.DstBridge.(mappingTokenMonitor)
+	if !ok {
+		logWorker(tokenMonitor, "no need to start mapping token monitor job as not supported")
+		return
+	}
+	mongodb.MgoWaitGroup.Add(1)
+	go loopMappingTokenMonitor(monitor, serverCfg.MappingTokenMonitor)
+}
+
+// restoreMappingTokenPausedPairs pause pairs which are paused by alert before restart,
+// until they are opened again by admin `maintain` call.
+func restoreMappingTokenPausedPairs() {
+	pairIDs, err := mongodb.FindMappingTokenPausedPairs()
+	if err != nil {
+		logWorkerError(tokenMonitor, "find paused pairs failed", err)
+		return
+	}
+	for _, pairID := range pairIDs {
+		pairCfg := tokens.GetTokenPairConfig(pairID)
+		if pairCfg == nil {
+			continue
+		}
+		pairCfg.SrcToken.DisableSwap = true
+		pairCfg.DestToken.DisableSwap = true
+		logWorkerWarn(tokenMonitor, "restore paused pair of mapping token alert", "pairID", pairID)
+	}
+}
+
+func loopMappingTokenMonitor(monitor mappingTokenMonitor, cfg *params.MappingTokenMonitorConfig) {
+	defer mongodb.MgoWaitGroup.Done()
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultTokenMonitorInterval
+	}
+	logWorker(tokenMonitor, "start mapping token monitor job", "interval", interval)
+	for {
+		scanMappingTokenEvents(monitor, cfg)
+		if utils.IsCleanuping() {
+			logWorker(tokenMonitor, "stop mapping token monitor job")
+			return
+		}
+		restInJob(time.Duration(interval) * time.Second)
+	}
+}
+
+// getMonitoredContracts get mapping token contracts and their pairIDs
+func getMonitoredContracts() map[string][]string {
+	contracts := make(map[string][]string)
+	for pairID, pairCfg := range tokens.GetTokenPairsConfig() {
+		contract := strings.ToLower(pairCfg.DestToken.ContractAddress)
+		if contract != "" {
+			contracts[contract] = append(contracts[contract], pairID)
+		}
+	}
+	return contracts
+}
+
+// scanMappingTokenEvents scan stable blocks since last scanned height
+func scanMappingTokenEvents(monitor mappingTokenMonitor, cfg *params.MappingTokenMonitorConfig) {
+	latest := tokens.DstLatestBlockHeight
+	confirmations := tokens.GetStableConfirmations(false)
+	if latest <= confirmations {
+		return
+	}
+	stableHeight := latest - confirmations
+
+	scannedHeight, err := mongodb.FindMappingTokenMonitorHeight()
+	if err != nil {
+		logWorkerError(tokenMonitor, "find scanned height failed", err)
+		return
+	}
+	if scannedHeight == 0 {
+		if cfg.StartHeight == 0 {
+			_ = mongodb.UpdateMappingTokenMonitorHeight(stableHeight)
+			return
+		}
+		scannedHeight = cfg.StartHeight - 1
+	}
+
+	blockRange := cfg.BlockRange
+	if blockRange == 0 {
+		blockRange = defaultTokenMonitorBlockRange
+	}
+	contracts := getMonitoredContracts()
+	if len(contracts) == 0 {
+		return
+	}
+	addresses := make([]string, 0, len(contracts))
+	for contract := range contracts {
+		addresses = append(addresses, contract)
+	}
+
+	for scannedHeight < stableHeight {
+		if utils.IsCleanuping() {
+			return
+		}
+		from := scannedHeight + 1
+		to := from + blockRange - 1
+		if to > stableHeight {
+			to = stableHeight
+		}
+		events, err := monitor.ScanMappingTokenEvents(addresses, from, to)
+		if err != nil {
+			logWorkerError(tokenMonitor, "scan mapping token events failed", err, "from", from, "to", to)
+			return
+		}
+		for _, event := range events {
+			err = processMappingTokenEvent(event, contracts[strings.ToLower(event.Contract)], cfg)
+			if err != nil {
+				logWorkerError(tokenMonitor, "process mapping token event failed", err, "contract", event.Contract, "txHash", event.TxHash)
+				return // retry this range later
+			}
+		}
+		logWorkerTrace(tokenMonitor, "scan mapping token events success", "from", from, "to", to, "events", len(events))
+		if err = mongodb.UpdateMappingTokenMonitorHeight(to); err != nil {
+			return
+		}
+		scannedHeight = to
+	}
+}
+
+func processMappingTokenEvent(event *tokens.MappingTokenEvent, pairIDs []string, cfg *params.MappingTokenMonitorConfig) error {
+	switch event.EventType {
+	case tokens.MappingTokenMint:
+		matched, err := isMintMatchSwapResult(event, pairIDs)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			alertMappingTokenEvent(event, pairIDs, "mint is not matched to any swap result", cfg)
+		}
+	case tokens.MappingTokenBurn:
+		logWorkerTrace(tokenMonitor, "found mapping token burn", "contract", event.Contract, "txHash", event.TxHash, "account", event.Account, "value", event.Value)
+	case tokens.MappingTokenRoleChange:
+		alertMappingTokenEvent(event, pairIDs, "privilege of mapping token is changed", cfg)
+	}
+	return nil
+}
+
+// isMintMatchSwapResult a mint is matched if it is the swap tx (or replaced swap tx)
+// of a swapin result of the same token with the same swap value
+func isMintMatchSwapResult(event *tokens.MappingTokenEvent, pairIDs []string) (bool, error) {
+	results, err := mongodb.FindSwapinResultsBySwapTx(event.TxHash)
+	if err != nil {
+		return false, err
+	}
+	value := event.Value.String()
+	for _, res := range results {
+		if !isInPairIDs(res.PairID, pairIDs) {
+			continue
+		}
+		if res.SwapValue == value {
+			return true, nil
+		}
+		for _, oldValue := range res.OldSwapVals {
+			if oldValue == value {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func isInPairIDs(pairID string, pairIDs []string) bool {
+	for _, id := range pairIDs {
+		if strings.EqualFold(id, pairID) {
+			return true
+		}
+	}
+	return false
+}
+
+func alertMappingTokenEvent(event *tokens.MappingTokenEvent, pairIDs []string, reason string, cfg *params.MappingTokenMonitorConfig) {
+	result := reason
+	if cfg.PauseOnAlert {
+		for _, pairID := range pairIDs {
+			pairCfg := tokens.GetTokenPairConfig(pairID)
+			if pairCfg == nil {
+				continue
+			}
+			pairCfg.SrcToken.DisableSwap = true
+			pairCfg.DestToken.DisableSwap = true
+		}
+		if err := mongodb.AddMappingTokenPausedPairs(pairIDs); err != nil {
+			logWorkerError(tokenMonitor, "save paused pairs failed", err, "pairIDs", pairIDs)
+		}
+		result += fmt.Sprintf(", paused pairs %v", pairIDs)
+	}
+	logWorkerWarn(tokenMonitor, "mapping token alert", "reason", reason, "pairIDs", pairIDs,
+		"contract", event.Contract, "event", event.EventName, "txHash", event.TxHash,
+		"height", event.BlockHeight, "account", event.Account, "value", event.Value, "paused", cfg.PauseOnAlert)
+	logParams := []string{event.Contract, event.EventName, event.TxHash, event.Account, event.Value.String()}
+	_ = mongodb.AddAdminLog(tokenMonitor, event.EventType, logParams, result)
+
+	if params.GetAlertWebhook() != "" {
+		go postMappingTokenAlert(reason, map[string]interface{}{
+			"pairIDs":  pairIDs,
+			"contract": event.Contract,
+			"event":    event.EventName,
+			"txHash":   event.TxHash,
+			"height":   event.BlockHeight,
+			"account":  event.Account,
+			"value":    event.Value.String(),
+			"paused":   cfg.PauseOnAlert,
+		})
+	}
+}
+
+func postMappingTokenAlert(subject string, content map[string]interface{}) {
+	err := tools.PostAlert(params.GetAlertWebhook(), subject, content)
+	if err != nil {
+		logWorkerWarn(tokenMonitor, "post mapping token alert failed", "subject", subject, "err", err)
+	}
+}
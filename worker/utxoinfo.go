@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+)
+
+var (
+	utxoInfoInterval = 5 * time.Minute
+
+	maxP2shBalancesInUtxoInfo = 100
+)
+
+// StartUtxoInfoJob update cached utxo info of btc bridge periodically,
+// the public api only returns the cached result
+func StartUtxoInfoJob() {
+	if btc.BridgeInstance == nil {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopUpdateUtxoInfo()
+}
+
+func loopUpdateUtxoInfo() {
+	defer mongodb.MgoWaitGroup.Done()
+	for {
+		if utils.IsCleanuping() {
+			return
+		}
+		info, err := calcUtxoInfo()
+		if err != nil {
+			logWorkerError("utxoinfo", "update utxo info failed", err)
+		} else {
+			btc.SetCachedUtxoInfo(info)
+			logWorker("utxoinfo", "update utxo info success", "utxos", info.UtxoCount, "p2shUtxos", info.P2shUtxoCount,
+				"pendingSwapouts", info.PendingSwapoutCount, "projectedBalance", info.ProjectedBalance)
+		}
+		restInJob(utxoInfoInterval)
+	}
+}
+
+func calcUtxoInfo() (*tokens.UtxoInfo, error) {
+	var dcrmAddress string
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		dcrmAddress = pairCfg.SrcToken.DcrmAddress
+		break
+	}
+	info, err := btc.GetUtxoInfo(btc.BridgeInstance, dcrmAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingValues, err := getPendingSwapoutValues()
+	if err != nil {
+		return nil, err
+	}
+	btc.ProjectPendingSwapouts(info, pendingValues)
+
+	var p2shBalances []*tokens.P2shBalance
+	for offset := 0; ; offset += utxoPageLimit {
+		p2shAddrs, err := mongodb.FindP2shAddresses(offset, utxoPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, p2shAddr := range p2shAddrs {
+			balance, err := btc.GetP2shBalance(btc.BridgeInstance, p2shAddr.Key, p2shAddr.P2shAddress)
+			if err != nil {
+				return nil, err
+			}
+			if balance.UtxoCount == 0 {
+				continue
+			}
+			info.P2shUtxoCount += balance.UtxoCount
+			info.P2shTotalValue += balance.Value
+			p2shBalances = append(p2shBalances, balance)
+		}
+		if len(p2shAddrs) < utxoPageLimit {
+			break
+		}
+	}
+	sort.Slice(p2shBalances, func(i, j int) bool {
+		return p2shBalances[i].Value > p2shBalances[j].Value
+	})
+	if len(p2shBalances) > maxP2shBalancesInUtxoInfo {
+		p2shBalances = p2shBalances[:maxP2shBalancesInUtxoInfo]
+	}
+	info.P2shBalances = p2shBalances
+	info.ShouldAggregate = btc.BridgeInstance.ShouldAggregate(info.P2shUtxoCount, info.P2shTotalValue)
+	info.UpdateTime = now()
+	return info, nil
+}
+
+// getPendingSwapoutValues get values to pay of swapouts not sent yet
+func getPendingSwapoutValues() ([]uint64, error) {
+	results, err := mongodb.FindSwapoutResultsWithStatus(mongodb.MatchTxEmpty, 0)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]uint64, 0, len(results))
+	for _, res := range results {
+		if !strings.EqualFold(res.PairID, btc.PairID) {
+			continue
+		}
+		value, ok := new(big.Int).SetString(res.Value, 10)
+		if !ok {
+			continue
+		}
+		swapValue := tokens.CalcSwappedValue(res.PairID, value, false, res.From, res.TxTo)
+		if swapValue.Sign() > 0 {
+			values = append(values, swapValue.Uint64())
+		}
+	}
+	return values, nil
+}
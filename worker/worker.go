@@ -57,11 +57,26 @@ func StartWork(isServer bool) {
 	StartReplaceJob()
 	time.Sleep(interval)
 
+	StartNonceAuditJob()
+	time.Sleep(interval)
+
 	StartPassBigValueJob()
 	time.Sleep(interval)
 
 	StartAggregateJob()
 	time.Sleep(interval)
 
+	StartUtxoInfoJob()
+	time.Sleep(interval)
+
+	StartSweepJob()
+	time.Sleep(interval)
+
+	StartGasAirdropJob()
+	time.Sleep(interval)
+
+	StartMappingTokenMonitorJob()
+	time.Sleep(interval)
+
 	StartCheckFailedSwapJob()
 }